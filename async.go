@@ -0,0 +1,169 @@
+package fylay
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"fyne.io/fyne/v2"
+	fynedialog "fyne.io/fyne/v2/dialog"
+)
+
+// attrBusyTarget indica l'ID del widget da disabilitare mentre un handler
+// asincrono è in esecuzione, al posto dell'elemento che ha generato l'evento
+const attrBusyTarget = "busy-target"
+
+// AsyncEventCallback è una callback eseguita fuori dal thread UI.
+// Il context viene annullato quando l'evento si ripete o con CancelAsync.
+type AsyncEventCallback func(ctx context.Context, ev *EventContext) error
+
+// AsyncErrorHandler riceve gli errori delle callback asincrone sul thread di Fyne
+type AsyncErrorHandler func(ev *EventContext, err error)
+
+// asyncKey identifica le esecuzioni di un handler per un elemento
+type asyncKey struct {
+	elem *Element
+	name string
+}
+
+// asyncRun è un'esecuzione in corso di un handler asincrono
+type asyncRun struct {
+	cancel  context.CancelFunc
+	restore func() // Riabilita il widget occupato, nil se non è stato disabilitato
+}
+
+// OnAsync registra una callback asincrona per un evento.
+// La callback gira in una goroutine: gli aggiornamenti della UI vanno fatti
+// con Update o fyne.Do. Durante l'esecuzione il widget che ha generato
+// l'evento, o quello indicato da busy-target, è disabilitato. Se l'evento
+// si ripete l'esecuzione precedente viene annullata. Gli errori, esclusi
+// quelli di annullamento, sono passati all'handler impostato con
+// SetAsyncErrorHandler o mostrati in un dialog.
+//
+// Le esecuzioni vengono annullate quando Build, Rebuild o Reset sostituiscono
+// l'interfaccia, e alla chiusura della finestra legata con BindWindow:
+//
+//	builder.BindWindow(window, nil)
+func (b *Builder) OnAsync(eventName string, callback AsyncEventCallback) {
+	b.On(eventName, func(ev *EventContext) {
+		b.startAsync(ev, callback)
+	})
+}
+
+// SetAsyncErrorHandler imposta l'handler degli errori delle callback asincrone
+func (b *Builder) SetAsyncErrorHandler(handler AsyncErrorHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.asyncErrorHandler = handler
+}
+
+// BindWindow lega il builder alla finestra che mostra l'interfaccia: alla
+// sua chiusura le callback asincrone in esecuzione vengono annullate, poi
+// viene chiamata onClosed se non è nil. Fyne non permette di leggere la
+// callback di chiusura già impostata, quindi quella dell'applicazione va
+// passata qui invece che a window.SetOnClosed, che sostituirebbe questa.
+func (b *Builder) BindWindow(window fyne.Window, onClosed func()) {
+	window.SetOnClosed(func() {
+		b.CancelAsync()
+		if onClosed != nil {
+			onClosed()
+		}
+	})
+}
+
+// CancelAsync annulla tutte le callback asincrone in esecuzione
+func (b *Builder) CancelAsync() {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, run := range b.asyncRuns {
+		run.cancel()
+	}
+}
+
+// startAsync avvia una callback asincrona annullando l'esecuzione precedente
+func (b *Builder) startAsync(ev *EventContext, callback AsyncEventCallback) {
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &asyncRun{cancel: cancel}
+	key := asyncKey{elem: ev.Element, name: ev.EventName}
+
+	b.mu.Lock()
+	if prev, ok := b.asyncRuns[key]; ok {
+		prev.cancel()
+		// Il widget è ancora disabilitato dall'esecuzione precedente
+		run.restore = prev.restore
+	} else {
+		run.restore = disableBusyTarget(b.busyTarget(ev))
+	}
+	b.asyncRuns[key] = run
+	b.mu.Unlock()
+
+	go func() {
+		err := callback(ctx, ev)
+		cancel()
+
+		fyne.Do(func() {
+			b.mu.Lock()
+			// Un'esecuzione sostituita lascia il widget a quella successiva
+			current := b.asyncRuns[key] == run
+			if current {
+				delete(b.asyncRuns, key)
+				if run.restore != nil {
+					run.restore()
+				}
+			}
+			b.mu.Unlock()
+
+			if current && err != nil && !errors.Is(err, context.Canceled) {
+				b.reportAsyncError(ev, err)
+			}
+		})
+	}()
+}
+
+// busyTarget restituisce il widget da disabilitare durante l'esecuzione
+func (b *Builder) busyTarget(ev *EventContext) fyne.CanvasObject {
+	if ev.Element != nil {
+		if id := ev.Element.GetAttr(attrBusyTarget); id != "" {
+			return b.GetWidget(id)
+		}
+	}
+	return ev.Target
+}
+
+// disableBusyTarget disabilita un widget se abilitato e restituisce la
+// funzione che lo riabilita
+func disableBusyTarget(obj fyne.CanvasObject) func() {
+	w, ok := obj.(fyne.Disableable)
+	if !ok || w.Disabled() {
+		return nil
+	}
+	w.Disable()
+	return w.Enable
+}
+
+// reportAsyncError passa l'errore all'handler configurato o lo mostra in un
+// dialog nella finestra dell'elemento
+func (b *Builder) reportAsyncError(ev *EventContext, err error) {
+	b.mu.RLock()
+	handler := b.asyncErrorHandler
+	b.mu.RUnlock()
+
+	if handler != nil {
+		handler(ev, err)
+		return
+	}
+
+	if ev.Target != nil && fyne.CurrentApp() != nil {
+		drv := fyne.CurrentApp().Driver()
+		if c := drv.CanvasForObject(ev.Target); c != nil {
+			for _, w := range drv.AllWindows() {
+				if w.Canvas() == c {
+					fynedialog.ShowError(err, w)
+					return
+				}
+			}
+		}
+	}
+
+	log.Printf("fylay: async handler %s failed: %v", ev.EventName, err)
+}
@@ -0,0 +1,213 @@
+package fylay
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+const asyncLayoutXML = `
+<Layout>
+	<VBox>
+		<Entry id="query" onchange="search" busy-target="goBtn" />
+		<Button id="goBtn" onclick="search">Go</Button>
+	</VBox>
+</Layout>
+`
+
+func TestOnAsync(t *testing.T) {
+	_ = test.NewApp()
+
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(asyncLayoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	started := make(chan string, 2)
+	canceled := make(chan string, 2)
+	release := make(chan struct{})
+	builder.OnAsync("search", func(ctx context.Context, ev *EventContext) error {
+		started <- ev.Value
+		select {
+		case <-ctx.Done():
+			canceled <- ev.Value
+			return ctx.Err()
+		case <-release:
+			return errors.New("search failed: " + ev.Value)
+		}
+	})
+
+	errs := make(chan error, 2)
+	builder.SetAsyncErrorHandler(func(ev *EventContext, err error) {
+		errs <- err
+	})
+
+	entry := builder.GetWidget("query").(*widget.Entry)
+	btn := builder.GetWidget("goBtn").(*widget.Button)
+
+	entry.SetText("a")
+	<-started
+	if !btn.Disabled() {
+		t.Error("Expected busy target to be disabled while running")
+	}
+
+	// Re-triggering cancels the previous run
+	entry.SetText("ab")
+	if got := <-canceled; got != "a" {
+		t.Errorf("Expected first run to be canceled, got %q", got)
+	}
+	<-started
+	if !btn.Disabled() {
+		t.Error("Expected busy target to stay disabled for the new run")
+	}
+
+	close(release)
+	select {
+	case err := <-errs:
+		if err.Error() != "search failed: ab" {
+			t.Errorf("Unexpected error %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected error handler to be called")
+	}
+	if btn.Disabled() {
+		t.Error("Expected busy target to be enabled after completion")
+	}
+	if len(errs) != 0 {
+		t.Error("Expected canceled run not to report errors")
+	}
+}
+
+func TestCancelAsync(t *testing.T) {
+	_ = test.NewApp()
+
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(asyncLayoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	started := make(chan struct{})
+	done := make(chan error)
+	builder.OnAsync("search", func(ctx context.Context, ev *EventContext) error {
+		close(started)
+		<-ctx.Done()
+		done <- ctx.Err()
+		return ctx.Err()
+	})
+	builder.SetAsyncErrorHandler(func(ev *EventContext, err error) {
+		t.Errorf("Unexpected error report: %v", err)
+	})
+
+	btn := builder.GetWidget("goBtn").(*widget.Button)
+	test.Tap(btn)
+	<-started
+	if !btn.Disabled() {
+		t.Error("Expected triggering button to be disabled while running")
+	}
+
+	builder.CancelAsync()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	// The run is removed once its completion, including the restore, is done
+	running := func() bool {
+		builder.mu.RLock()
+		defer builder.mu.RUnlock()
+		return len(builder.asyncRuns) > 0
+	}
+	deadline := time.Now().Add(time.Second)
+	for running() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if running() || btn.Disabled() {
+		t.Error("Expected button to be enabled after cancellation")
+	}
+}
+
+func TestAsyncCanceledOnRebuild(t *testing.T) {
+	_ = test.NewApp()
+
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(asyncLayoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	started := make(chan struct{}, 1)
+	done := make(chan error, 1)
+	builder.OnAsync("search", func(ctx context.Context, ev *EventContext) error {
+		started <- struct{}{}
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+		}
+		done <- ctx.Err()
+		return ctx.Err()
+	})
+
+	test.Tap(builder.GetWidget("goBtn").(*widget.Button))
+	<-started
+	if _, err := builder.Rebuild(layout); err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Rebuild to cancel the run, got %v", err)
+	}
+}
+
+func TestAsyncCanceledOnWindowClose(t *testing.T) {
+	_ = test.NewApp()
+
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(asyncLayoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	content, err := builder.Build(layout)
+	if err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	w := test.NewWindow(content)
+	closed := false
+	builder.BindWindow(w, func() { closed = true })
+
+	started := make(chan struct{}, 1)
+	done := make(chan error, 1)
+	builder.OnAsync("search", func(ctx context.Context, ev *EventContext) error {
+		started <- struct{}{}
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+		}
+		done <- ctx.Err()
+		return ctx.Err()
+	})
+
+	test.Tap(builder.GetWidget("goBtn").(*widget.Button))
+	<-started
+	w.Close()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected closing the window to cancel the run, got %v", err)
+	}
+	if !closed {
+		t.Error("Expected the application close callback to be called")
+	}
+}
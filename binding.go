@@ -2,18 +2,27 @@ package fylay
 
 import (
 	"fmt"
-	"strings"
+	"sync"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/widget"
 )
 
-// BindingContext manages data bindings for the layout
+// BindingContext manages data bindings for the layout.
+// It is safe for concurrent use.
 type BindingContext struct {
+	mu       sync.RWMutex
 	data     map[string]binding.DataItem
 	widgets  map[string]fyne.CanvasObject
 	bindings map[string][]string // widget ID -> bound data keys
+	rows     map[string]*listRow // Row scopes of lists and trees, by key
+
+	converters map[string]converter // Custom converters for bind expressions
+	computed   map[string]*computed // Computed bindings by key
+	watchers   map[*computed]bool   // Active computations, followed when their keys are bound again
+	persisted  map[string]bool      // Keys to store in the preferences once bound
+	history    *bindingHistory      // Undo history, nil until EnableHistory
 }
 
 // NewBindingContext creates a new binding context
@@ -22,78 +31,131 @@ func NewBindingContext() *BindingContext {
 		data:     make(map[string]binding.DataItem),
 		widgets:  make(map[string]fyne.CanvasObject),
 		bindings: make(map[string][]string),
+		rows:     make(map[string]*listRow),
+
+		converters: make(map[string]converter),
+		computed:   make(map[string]*computed),
+		watchers:   make(map[*computed]bool),
+		persisted:  make(map[string]bool),
 	}
 }
 
 // BindString binds a string data item to a key
 func (bc *BindingContext) BindString(key string, value string) binding.String {
-	if existingData, ok := bc.data[key]; ok {
-		if strData, ok := existingData.(binding.String); ok {
-			_ = strData.Set(value) //nolint:errcheck // Ignore error on set
-			return strData
-		}
+	bc.mu.Lock()
+	strData, ok := bc.resolveLocked(key).(binding.String)
+	if !ok {
+		strData = newBindingLocked(bc, key, binding.NewString(), value)
 	}
+	bc.mu.Unlock()
 
-	strData := binding.NewString()
-	_ = strData.Set(value) //nolint:errcheck // Ignore error on set
-	bc.data[key] = strData
+	// Set outside the lock: listeners may call back into the context
+	if ok {
+		_ = strData.Set(value) //nolint:errcheck // Ignore error on set
+	} else {
+		bc.stored(key, strData)
+	}
 	return strData
 }
 
 // BindInt binds an integer data item to a key
 func (bc *BindingContext) BindInt(key string, value int) binding.Int {
-	if existingData, ok := bc.data[key]; ok {
-		if intData, ok := existingData.(binding.Int); ok {
-			_ = intData.Set(value) //nolint:errcheck // Ignore error on set
-			return intData
-		}
+	bc.mu.Lock()
+	intData, ok := bc.resolveLocked(key).(binding.Int)
+	if !ok {
+		intData = newBindingLocked(bc, key, binding.NewInt(), value)
 	}
+	bc.mu.Unlock()
 
-	intData := binding.NewInt()
-	_ = intData.Set(value) //nolint:errcheck // Ignore error on set
-	bc.data[key] = intData
+	if ok {
+		_ = intData.Set(value) //nolint:errcheck // Ignore error on set
+	} else {
+		bc.stored(key, intData)
+	}
 	return intData
 }
 
 // BindFloat binds a float data item to a key
 func (bc *BindingContext) BindFloat(key string, value float64) binding.Float {
-	if existingData, ok := bc.data[key]; ok {
-		if floatData, ok := existingData.(binding.Float); ok {
-			_ = floatData.Set(value) //nolint:errcheck // Ignore error on set
-			return floatData
-		}
+	bc.mu.Lock()
+	floatData, ok := bc.resolveLocked(key).(binding.Float)
+	if !ok {
+		floatData = newBindingLocked(bc, key, binding.NewFloat(), value)
 	}
+	bc.mu.Unlock()
 
-	floatData := binding.NewFloat()
-	_ = floatData.Set(value) //nolint:errcheck // Ignore error on set
-	bc.data[key] = floatData
+	if ok {
+		_ = floatData.Set(value) //nolint:errcheck // Ignore error on set
+	} else {
+		bc.stored(key, floatData)
+	}
 	return floatData
 }
 
 // BindBool binds a boolean data item to a key
 func (bc *BindingContext) BindBool(key string, value bool) binding.Bool {
-	if existingData, ok := bc.data[key]; ok {
-		if boolData, ok := existingData.(binding.Bool); ok {
-			_ = boolData.Set(value) //nolint:errcheck // Ignore error on set
-			return boolData
-		}
+	bc.mu.Lock()
+	boolData, ok := bc.resolveLocked(key).(binding.Bool)
+	if !ok {
+		boolData = newBindingLocked(bc, key, binding.NewBool(), value)
 	}
+	bc.mu.Unlock()
 
-	boolData := binding.NewBool()
-	_ = boolData.Set(value) //nolint:errcheck // Ignore error on set
-	bc.data[key] = boolData
+	if ok {
+		_ = boolData.Set(value) //nolint:errcheck // Ignore error on set
+	} else {
+		bc.stored(key, boolData)
+	}
 	return boolData
 }
 
-// GetBinding retrieves a binding by key
+// BindStringList binds a string list data item to a key
+func (bc *BindingContext) BindStringList(key string, values []string) binding.StringList {
+	bc.mu.Lock()
+	listData, ok := bc.resolveLocked(key).(binding.StringList)
+	if !ok {
+		listData = newBindingLocked(bc, key, binding.NewStringList(), values)
+	}
+	bc.mu.Unlock()
+
+	if ok {
+		_ = listData.Set(values) //nolint:errcheck // Ignore error on set
+	} else {
+		bc.stored(key, listData)
+	}
+	return listData
+}
+
+// BindList binds a list of values of any type, e.g. structs or maps, to a
+// key. The items are rendered by <List bind="key">.
+func (bc *BindingContext) BindList(key string, items []any) binding.UntypedList {
+	bc.mu.Lock()
+	listData, ok := bc.resolveLocked(key).(binding.UntypedList)
+	if !ok {
+		listData = newBindingLocked(bc, key, binding.NewList(sameValue), items)
+	}
+	bc.mu.Unlock()
+
+	if ok {
+		_ = listData.Set(items) //nolint:errcheck // Ignore error on set
+	} else {
+		bc.stored(key, listData)
+	}
+	return listData
+}
+
+// GetBinding retrieves a binding by key.
+// Keys can be nested paths into bound structs and maps, e.g. "user.Name".
 func (bc *BindingContext) GetBinding(key string) (binding.DataItem, bool) {
-	data, ok := bc.data[key]
-	return data, ok
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	data := bc.resolveLocked(key)
+	return data, data != nil
 }
 
 // GetString retrieves a string binding value
 func (bc *BindingContext) GetString(key string) (string, error) {
-	data, ok := bc.data[key]
+	data, ok := bc.GetBinding(key)
 	if !ok {
 		return "", fmt.Errorf("binding not found: %s", key)
 	}
@@ -108,7 +170,7 @@ func (bc *BindingContext) GetString(key string) (string, error) {
 
 // GetInt retrieves an integer binding value
 func (bc *BindingContext) GetInt(key string) (int, error) {
-	data, ok := bc.data[key]
+	data, ok := bc.GetBinding(key)
 	if !ok {
 		return 0, fmt.Errorf("binding not found: %s", key)
 	}
@@ -123,7 +185,7 @@ func (bc *BindingContext) GetInt(key string) (int, error) {
 
 // GetFloat retrieves a float binding value
 func (bc *BindingContext) GetFloat(key string) (float64, error) {
-	data, ok := bc.data[key]
+	data, ok := bc.GetBinding(key)
 	if !ok {
 		return 0, fmt.Errorf("binding not found: %s", key)
 	}
@@ -138,7 +200,7 @@ func (bc *BindingContext) GetFloat(key string) (float64, error) {
 
 // GetBool retrieves a boolean binding value
 func (bc *BindingContext) GetBool(key string) (bool, error) {
-	data, ok := bc.data[key]
+	data, ok := bc.GetBinding(key)
 	if !ok {
 		return false, fmt.Errorf("binding not found: %s", key)
 	}
@@ -151,25 +213,157 @@ func (bc *BindingContext) GetBool(key string) (bool, error) {
 	return boolData.Get()
 }
 
+// GetStringList retrieves a string list binding value
+func (bc *BindingContext) GetStringList(key string) ([]string, error) {
+	data, ok := bc.GetBinding(key)
+	if !ok {
+		return nil, fmt.Errorf("binding not found: %s", key)
+	}
+
+	listData, ok := data.(binding.StringList)
+	if !ok {
+		return nil, fmt.Errorf("binding is not a string list: %s", key)
+	}
+
+	return listData.Get()
+}
+
+// GetValue retrieves the value of a binding of any supported type
+func (bc *BindingContext) GetValue(key string) (any, error) {
+	data, ok := bc.GetBinding(key)
+	if !ok {
+		return nil, fmt.Errorf("binding not found: %s", key)
+	}
+
+	return dataValue(data)
+}
+
+// stringBinding returns the binding of a bind expression as a string binding.
+// Int, float, bool and untyped bindings are converted both ways; a new string
+// binding holding value is created if the key is not bound yet. It returns nil
+// if the key holds a binding of another type or a converter is unknown.
+func (bc *BindingContext) stringBinding(expr, value string) binding.String {
+	data := bc.convertedBinding(expr, func() binding.DataItem {
+		strData := binding.NewString()
+		_ = strData.Set(value) //nolint:errcheck // Ignore error on set
+		return strData
+	})
+
+	switch d := data.(type) {
+	case binding.String:
+		return d
+	case binding.Int:
+		return binding.IntToString(d)
+	case binding.Float:
+		return binding.FloatToString(d)
+	case binding.Bool:
+		return binding.BoolToString(d)
+	case binding.Untyped:
+		return untypedString{d}
+	default:
+		return nil
+	}
+}
+
+// boolBinding returns the binding of a bind expression as a bool binding,
+// converting string and untyped bindings. A new bool binding holding value is
+// created if the key is not bound yet. It returns nil if the key holds a
+// binding of another type or a converter is unknown.
+func (bc *BindingContext) boolBinding(expr string, value bool) binding.Bool {
+	data := bc.convertedBinding(expr, func() binding.DataItem {
+		boolData := binding.NewBool()
+		_ = boolData.Set(value) //nolint:errcheck // Ignore error on set
+		return boolData
+	})
+
+	switch d := data.(type) {
+	case binding.Bool:
+		return d
+	case binding.String:
+		return binding.StringToBool(d)
+	case binding.Untyped:
+		return binding.StringToBool(untypedString{d})
+	default:
+		return nil
+	}
+}
+
+// floatBinding returns the binding of a bind expression as a float binding,
+// converting int, string and untyped bindings. A new float binding holding
+// value is created if the key is not bound yet. It returns nil if the key
+// holds a binding of another type or a converter is unknown.
+func (bc *BindingContext) floatBinding(expr string, value float64) binding.Float {
+	data := bc.convertedBinding(expr, func() binding.DataItem {
+		floatData := binding.NewFloat()
+		_ = floatData.Set(value) //nolint:errcheck // Ignore error on set
+		return floatData
+	})
+
+	switch d := data.(type) {
+	case binding.Float:
+		return d
+	case binding.Int:
+		return binding.IntToFloat(d)
+	case binding.String:
+		return binding.StringToFloat(d)
+	case binding.Untyped:
+		return binding.StringToFloat(untypedString{d})
+	default:
+		return nil
+	}
+}
+
+// stored follows a new binding stored under key: the history records it and
+// the computations using key move to it. The caller must not hold bc.mu.
+func (bc *BindingContext) stored(key string, data binding.DataItem) {
+	bc.track(key, data)
+	bc.rebound(key)
+}
+
+// getOrCreate returns the binding for a key, storing the one returned by
+// create if the key is not bound yet. Persisted keys get a binding of the
+// same type backed by the app preferences.
+func (bc *BindingContext) getOrCreate(key string, create func() binding.DataItem) binding.DataItem {
+	bc.mu.Lock()
+	if data := bc.resolveLocked(key); data != nil {
+		bc.mu.Unlock()
+		return data
+	}
+
+	data := create()
+	if name, ok := bc.preferenceNameLocked(key); ok {
+		data = preferenceBinding(name, data)
+	}
+	bc.data[key] = data
+	bc.mu.Unlock()
+
+	bc.stored(key, data)
+	return data
+}
+
 // RegisterWidget registers a widget with an ID for binding
 func (bc *BindingContext) RegisterWidget(id string, w fyne.CanvasObject) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
 	bc.widgets[id] = w
 }
 
 // GetWidget retrieves a registered widget by ID
 func (bc *BindingContext) GetWidget(id string) (fyne.CanvasObject, bool) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
 	w, ok := bc.widgets[id]
 	return w, ok
 }
 
 // BindWidgetToData binds a widget to a data key
 func (bc *BindingContext) BindWidgetToData(widgetID string, dataKey string) error {
-	w, ok := bc.widgets[widgetID]
+	w, ok := bc.GetWidget(widgetID)
 	if !ok {
 		return fmt.Errorf("widget not found: %s", widgetID)
 	}
 
-	data, ok := bc.data[dataKey]
+	data, ok := bc.GetBinding(dataKey)
 	if !ok {
 		return fmt.Errorf("binding not found: %s", dataKey)
 	}
@@ -209,26 +403,33 @@ func (bc *BindingContext) BindWidgetToData(widgetID string, dataKey string) erro
 	}
 
 	// Track binding
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
 	bc.bindings[widgetID] = append(bc.bindings[widgetID], dataKey)
 
 	return nil
 }
 
-// ParseBindAttribute parses a bind attribute value (e.g., "user.name")
-// Returns the key to use for the binding
+// ParseBindAttribute parses a bind attribute value (e.g., "user.Name")
+// Returns the key to use for the binding; nested paths are normalized
+// so "user . Name" becomes "user.Name" and converter pipes are dropped,
+// so "price | currency:EUR" becomes "price"
 func ParseBindAttribute(bindAttr string) string {
-	// For now, simple dot notation: "user.name" -> "user.name"
-	// Future: could support more complex expressions
-	return strings.TrimSpace(bindAttr)
+	key, _ := parseBindExpr(bindAttr)
+	return key
 }
 
 // SetBindingContext sets the binding context on the builder
 func (b *Builder) SetBindingContext(ctx *BindingContext) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	b.bindingContext = ctx
 }
 
 // GetBindingContext returns the builder's binding context
 func (b *Builder) GetBindingContext() *BindingContext {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	if b.bindingContext == nil {
 		b.bindingContext = NewBindingContext()
 	}
@@ -0,0 +1,250 @@
+package fylay
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// ComputeFunc computes the value of a computed binding from the values of
+// its dependencies, in the order they were given
+type ComputeFunc func(values []any) any
+
+// computed is the registration of a computed value on its dependencies.
+// The dependencies are resolved again whenever one of their keys is bound to
+// a new binding, e.g. by a typed Bind call after the layout is built.
+type computed struct {
+	bc     *BindingContext
+	keys   []string
+	fn     ComputeFunc
+	result binding.DataItem
+	name   string // Identifies the computation in logged errors
+
+	mu       sync.Mutex
+	deps     []binding.DataItem
+	listener binding.DataListener
+	stopped  bool
+}
+
+// stop removes the listener from the dependencies
+func (c *computed) stop() {
+	c.bc.mu.Lock()
+	delete(c.bc.watchers, c)
+	c.bc.mu.Unlock()
+
+	c.mu.Lock()
+	deps := c.deps
+	c.deps, c.stopped = nil, true
+	c.mu.Unlock()
+
+	for _, dep := range deps {
+		dep.RemoveListener(c.listener)
+	}
+}
+
+// Computed binds key to a value derived from other bindings. fn is called
+// again whenever one of deps changes; missing dependencies are bound to an
+// empty string until they are bound, e.g. with BindInt, which the computation
+// then follows. The result binding has the type of the first computed value
+// (string, int, float64, bool or []string, untyped otherwise), unless key is
+// already bound, e.g. by a widget. Calling Computed again for the same key
+// replaces the previous computation.
+//
+//	ctx.Computed("canSave", []string{"first", "last"}, func(v []any) any {
+//		return v[0] != "" && v[1] != ""
+//	})
+func (bc *BindingContext) Computed(key string, deps []string, fn ComputeFunc) binding.DataItem {
+	// Computed values are derived, the history records their dependencies
+	bc.ignoreHistory(key)
+
+	value := compute(bc.dependencies(deps), fn)
+	result := bc.getOrCreate(key, func() binding.DataItem {
+		return newValueBinding(value)
+	})
+	if err := setDataValue(result, value); err != nil {
+		log.Printf("fylay: computed %s: %v", key, err)
+	}
+
+	c := bc.watch(deps, fn, result, key)
+
+	bc.mu.Lock()
+	prev := bc.computed[key]
+	bc.computed[key] = c
+	bc.mu.Unlock()
+	if prev != nil {
+		prev.stop()
+	}
+
+	return result
+}
+
+// dependencies returns the bindings of keys, binding the missing ones to an
+// empty string
+func (bc *BindingContext) dependencies(keys []string) []binding.DataItem {
+	deps := make([]binding.DataItem, len(keys))
+	for i, key := range keys {
+		deps[i] = bc.getOrCreate(ParseBindAttribute(key), func() binding.DataItem {
+			return binding.NewString()
+		})
+	}
+	return deps
+}
+
+// compute calls fn with the current values of deps
+func compute(deps []binding.DataItem, fn ComputeFunc) any {
+	values := make([]any, len(deps))
+	for i, dep := range deps {
+		values[i], _ = dataValue(dep) //nolint:errcheck // Unreadable values are nil
+	}
+	return fn(values)
+}
+
+// watch sets result to the value computed by fn whenever one of the bindings
+// of keys changes, following the keys when they are bound again.
+// name identifies the computation in logged errors.
+func (bc *BindingContext) watch(keys []string, fn ComputeFunc, result binding.DataItem, name string) *computed {
+	normalized := make([]string, len(keys))
+	for i, key := range keys {
+		normalized[i] = ParseBindAttribute(key)
+	}
+	c := &computed{bc: bc, keys: normalized, fn: fn, result: result, name: name}
+	c.listener = binding.NewDataListener(c.update)
+
+	// Registered first, so keys bound while resolving are not missed
+	bc.mu.Lock()
+	bc.watchers[c] = true
+	bc.mu.Unlock()
+
+	c.refresh()
+	return c
+}
+
+// rebound resolves again the computations using a key, after it was bound
+// to a new binding. The caller must not hold bc.mu.
+func (bc *BindingContext) rebound(key string) {
+	var watchers []*computed
+	bc.mu.RLock()
+	for c := range bc.watchers {
+		for _, k := range c.keys {
+			if k == key || strings.HasPrefix(k, key+".") {
+				watchers = append(watchers, c)
+				break
+			}
+		}
+	}
+	bc.mu.RUnlock()
+
+	for _, c := range watchers {
+		c.refresh()
+	}
+}
+
+// refresh resolves the dependencies and moves the listener to the bindings
+// that changed
+func (c *computed) refresh() {
+	deps := c.bc.dependencies(c.keys)
+
+	c.mu.Lock()
+	if c.stopped || sameBindings(deps, c.deps) {
+		c.mu.Unlock()
+		return
+	}
+	old := c.deps
+	c.deps = deps
+	c.mu.Unlock()
+
+	for _, dep := range old {
+		dep.RemoveListener(c.listener)
+	}
+	// Adding the listener also computes the value again
+	for _, dep := range deps {
+		dep.AddListener(c.listener)
+	}
+}
+
+// update sets the result to the value computed from the dependencies
+func (c *computed) update() {
+	c.mu.Lock()
+	deps := c.deps
+	c.mu.Unlock()
+
+	if err := setDataValue(c.result, compute(deps, c.fn)); err != nil {
+		log.Printf("fylay: computed %s: %v", c.name, err)
+	}
+}
+
+// sameBindings reports whether two lists hold the same bindings. Bindings
+// that cannot be compared, such as list row items, are never the same.
+func sameBindings(a, b []binding.DataItem) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !reflect.TypeOf(a[i]).Comparable() || a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// computedExpr binds key to the value of an expression such as
+// "first + ' ' + last". The binding keys used in the expression are its
+// dependencies.
+func (bc *BindingContext) computedExpr(key, src string) error {
+	e, err := parseExpr(src)
+	if err != nil {
+		return err
+	}
+
+	bc.Computed(key, e.keys, func(values []any) any {
+		vars := make(map[string]any, len(values))
+		for i, k := range e.keys {
+			vars[k] = values[i]
+		}
+		v, err := e.eval(vars)
+		if err != nil {
+			log.Printf("fylay: computed %s: %v", key, err)
+			return nil
+		}
+		return v
+	})
+	return nil
+}
+
+// newValueBinding creates an empty binding of the type of v
+func newValueBinding(v any) binding.DataItem {
+	switch v.(type) {
+	case string:
+		return binding.NewString()
+	case bool:
+		return binding.NewBool()
+	case int:
+		return binding.NewInt()
+	case float64:
+		return binding.NewFloat()
+	case []string:
+		return binding.NewStringList()
+	default:
+		return binding.NewUntyped()
+	}
+}
+
+// buildComputed binds the key of a <Computed key expr> element to its
+// expression. The element has no visual representation.
+func (b *Builder) buildComputed(elem Element) (fyne.CanvasObject, error) {
+	key := ParseBindAttribute(elem.GetAttr("key"))
+	if key == "" {
+		return nil, errors.New("computed element requires a key")
+	}
+
+	if err := b.GetBindingContext().computedExpr(key, elem.GetAttr("expr")); err != nil {
+		return nil, fmt.Errorf("computed %s: %w", key, err)
+	}
+	return nil, nil
+}
@@ -0,0 +1,632 @@
+package fylay
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// ConverterFunc converts a value in a bind expression pipe, e.g.
+// bind="price | currency:EUR". arg is the text after the colon, empty if
+// the pipe has none.
+type ConverterFunc func(value any, arg string) (any, error)
+
+// converter converts bound values for display (to) and back (from).
+// A nil from makes the conversion one-way.
+type converter struct {
+	to   ConverterFunc
+	from ConverterFunc
+}
+
+// defaultDateLayout is the layout of the date converter without argument
+const defaultDateLayout = "2006-01-02"
+
+// dateLayouts are the layouts of the dates read from strings
+var dateLayouts = []string{time.RFC3339, defaultDateLayout}
+
+// builtinConverters are the converters available in every bind expression
+var builtinConverters = map[string]converter{
+	"int":      {to: convertInt, from: convertInt},
+	"float":    {to: formatFloat, from: convertFloat},
+	"bool":     {to: formatBool, from: parseBool},
+	"percent":  {to: formatPercent, from: parsePercent}, // 42 is "42 %", with percent:ratio 0.42 is too
+	"currency": {to: formatCurrency, from: parseCurrency},
+	"format":   {to: formatPrintf, from: parsePrintf},
+	"date":     {to: formatDate, from: parseDate},
+}
+
+// currencySymbols are the symbols written in place of known currency codes
+var currencySymbols = map[string]string{
+	"EUR": "€",
+	"USD": "$",
+	"GBP": "£",
+	"JPY": "¥",
+}
+
+// RegisterConverter registers a converter usable in bind expressions as
+// bind="key | name" or bind="key | name:arg". to converts the bound value for
+// the widget, from converts the widget value back; a nil from makes the
+// binding read-only. Custom converters replace built-in ones with the same
+// name. Converters must be registered before Build.
+func (bc *BindingContext) RegisterConverter(name string, to, from ConverterFunc) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.converters[name] = converter{to: to, from: from}
+}
+
+// bindPipe is a converter applied in a bind expression
+type bindPipe struct {
+	name string
+	arg  string
+	conv converter
+}
+
+// parseBindExpr splits a bind expression into the normalized key and the
+// pipes, e.g. "price | currency:EUR" into "price" and [currency EUR]
+func parseBindExpr(expr string) (string, []bindPipe) {
+	parts := strings.Split(expr, "|")
+
+	path := strings.Split(parts[0], ".")
+	for i, part := range path {
+		path[i] = strings.TrimSpace(part)
+	}
+	key := strings.Join(path, ".")
+
+	var pipes []bindPipe
+	for _, part := range parts[1:] {
+		name, arg, _ := strings.Cut(part, ":")
+		pipes = append(pipes, bindPipe{name: strings.TrimSpace(name), arg: strings.TrimSpace(arg)})
+	}
+
+	return key, pipes
+}
+
+// resolvePipes looks up the converters of the pipes. Custom converters win
+// over built-in ones.
+func (bc *BindingContext) resolvePipes(pipes []bindPipe) error {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	for i, p := range pipes {
+		conv, ok := bc.converters[p.name]
+		if !ok {
+			conv, ok = builtinConverters[p.name]
+		}
+		if !ok || conv.to == nil {
+			return fmt.Errorf("unknown converter %q", p.name)
+		}
+		pipes[i].conv = conv
+	}
+
+	return nil
+}
+
+// convertedBinding returns the binding of a bind expression. The binding of
+// the key is returned as is if the expression has no pipes, otherwise it is
+// wrapped in a string binding applying the converters. Single format pipes
+// use Fyne's own format adapters. It returns nil if a converter is unknown.
+func (bc *BindingContext) convertedBinding(expr string, create func() binding.DataItem) binding.DataItem {
+	key, pipes := parseBindExpr(expr)
+	data := bc.getOrCreate(key, create)
+	if len(pipes) == 0 {
+		return data
+	}
+
+	if err := bc.resolvePipes(pipes); err != nil {
+		log.Printf("fylay: invalid bind expression %q: %v", expr, err)
+		return nil
+	}
+
+	if len(pipes) == 1 && pipes[0].name == "format" && pipes[0].arg != "" {
+		if _, custom := bc.customConverter("format"); !custom {
+			switch d := data.(type) {
+			case binding.Float:
+				return binding.FloatToStringWithFormat(d, pipes[0].arg)
+			case binding.Int:
+				return binding.IntToStringWithFormat(d, pipes[0].arg)
+			case binding.Bool:
+				return binding.BoolToStringWithFormat(d, pipes[0].arg)
+			}
+		}
+	}
+
+	return &convertedString{DataItem: data, pipes: pipes}
+}
+
+// customConverter returns a converter registered with RegisterConverter
+func (bc *BindingContext) customConverter(name string) (converter, bool) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	conv, ok := bc.converters[name]
+	return conv, ok
+}
+
+// convertedString is a string binding that converts the value of another
+// binding through the pipes of a bind expression. Listeners are registered
+// on the source binding.
+type convertedString struct {
+	binding.DataItem
+	pipes []bindPipe
+}
+
+// Get returns the source value converted by the pipes
+func (c *convertedString) Get() (string, error) {
+	v, err := dataValue(c.DataItem)
+	if err != nil {
+		return "", err
+	}
+
+	for _, p := range c.pipes {
+		if v, err = p.conv.to(v, p.arg); err != nil {
+			return "", fmt.Errorf("converter %s: %w", p.name, err)
+		}
+	}
+
+	return formatValue(v), nil
+}
+
+// Set converts the value back through the pipes, in reverse order, and sets
+// the source binding
+func (c *convertedString) Set(s string) error {
+	var v any = s
+	for i := len(c.pipes) - 1; i >= 0; i-- {
+		p := c.pipes[i]
+		if p.conv.from == nil {
+			return fmt.Errorf("converter %s is one-way", p.name)
+		}
+
+		var err error
+		if v, err = p.conv.from(v, p.arg); err != nil {
+			return fmt.Errorf("converter %s: %w", p.name, err)
+		}
+	}
+
+	// Dates are written back in the form of the source value
+	if t, ok := v.(time.Time); ok {
+		if current, err := dataValue(c.DataItem); err == nil {
+			v = timeLike(t, current)
+		}
+	}
+
+	return setDataValue(c.DataItem, v)
+}
+
+// timeLike converts a time to the form of another value: a string in the
+// same date layout, Unix seconds for numbers, the time itself otherwise.
+// Strings that are not dates get the default date layout.
+func timeLike(t time.Time, like any) any {
+	switch like := like.(type) {
+	case string:
+		for _, layout := range dateLayouts {
+			if _, err := time.Parse(layout, strings.TrimSpace(like)); err == nil {
+				return t.Format(layout)
+			}
+		}
+		return t.Format(defaultDateLayout)
+	case int:
+		return int(t.Unix())
+	case float64:
+		return float64(t.Unix())
+	default:
+		return t
+	}
+}
+
+// dataValue returns the value of a binding of any supported type
+func dataValue(data binding.DataItem) (any, error) {
+	switch d := data.(type) {
+	case binding.String:
+		return d.Get()
+	case binding.Int:
+		return d.Get()
+	case binding.Float:
+		return d.Get()
+	case binding.Bool:
+		return d.Get()
+	case binding.StringList:
+		return d.Get()
+	case binding.UntypedList:
+		return d.Get()
+	case binding.Untyped:
+		return d.Get()
+	default:
+		return nil, fmt.Errorf("unsupported binding type %T", data)
+	}
+}
+
+// setDataValue sets a binding of any supported type, converting the value
+// to the type of the binding
+func setDataValue(data binding.DataItem, v any) error {
+	switch d := data.(type) {
+	case binding.String:
+		return d.Set(formatValue(v))
+	case binding.Int:
+		i, err := toInt(v)
+		if err != nil {
+			return err
+		}
+		return d.Set(i)
+	case binding.Float:
+		f, err := toFloat(v)
+		if err != nil {
+			return err
+		}
+		return d.Set(f)
+	case binding.Bool:
+		b, err := toBool(v)
+		if err != nil {
+			return err
+		}
+		return d.Set(b)
+	case binding.StringList:
+		list, ok := v.([]string)
+		if !ok {
+			return fmt.Errorf("cannot convert %T to []string", v)
+		}
+		return d.Set(list)
+	case binding.UntypedList:
+		list, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("cannot convert %T to []any", v)
+		}
+		return d.Set(list)
+	case binding.Untyped:
+		return d.Set(v)
+	default:
+		return fmt.Errorf("unsupported binding type %T", data)
+	}
+}
+
+// formatValue formats a converted value for display
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// toInt converts numbers and numeric strings to int
+func toInt(v any) (int, error) {
+	switch v := v.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case int32:
+		return int(v), nil
+	case float64:
+		return int(math.Round(v)), nil
+	case float32:
+		return int(math.Round(float64(v))), nil
+	case string:
+		s := strings.TrimSpace(v)
+		if i, err := strconv.Atoi(s); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("not an integer: %q", v)
+		}
+		return int(math.Round(f)), nil
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int", v)
+	}
+}
+
+// toFloat converts numbers and numeric strings to float64
+func toFloat(v any) (float64, error) {
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("not a number: %q", v)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("cannot convert %T to float", v)
+	}
+}
+
+// toBool converts booleans, numbers and strings like "true" to bool
+func toBool(v any) (bool, error) {
+	switch v := v.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return false, fmt.Errorf("not a boolean: %q", v)
+		}
+		return b, nil
+	default:
+		f, err := toFloat(v)
+		if err != nil {
+			return false, fmt.Errorf("cannot convert %T to bool", v)
+		}
+		return f != 0, nil
+	}
+}
+
+// toTime converts times, RFC 3339 or date strings and Unix seconds to time.Time
+func toTime(v any) (time.Time, error) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("not a date: %q", v)
+	default:
+		sec, err := toInt(v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cannot convert %T to date", v)
+		}
+		return time.Unix(int64(sec), 0), nil
+	}
+}
+
+// convertInt converts the value to int: "count | int"
+func convertInt(v any, _ string) (any, error) {
+	return toInt(v)
+}
+
+// convertFloat converts the value to float64
+func convertFloat(v any, _ string) (any, error) {
+	return toFloat(v)
+}
+
+// formatFloat formats a number with the decimals in arg: "ratio | float:2"
+func formatFloat(v any, arg string) (any, error) {
+	f, err := toFloat(v)
+	if err != nil {
+		return nil, err
+	}
+	prec, err := decimals(arg, -1)
+	if err != nil {
+		return nil, err
+	}
+	return strconv.FormatFloat(f, 'f', prec, 64), nil
+}
+
+// formatBool formats a boolean with the labels in arg: "active | bool:Yes/No"
+func formatBool(v any, arg string) (any, error) {
+	b, err := toBool(v)
+	if err != nil {
+		return nil, err
+	}
+	yes, no := boolLabels(arg)
+	if b {
+		return yes, nil
+	}
+	return no, nil
+}
+
+// parseBool parses a boolean written with the labels in arg
+func parseBool(v any, arg string) (any, error) {
+	if s, ok := v.(string); ok {
+		yes, no := boolLabels(arg)
+		switch {
+		case strings.EqualFold(strings.TrimSpace(s), yes):
+			return true, nil
+		case strings.EqualFold(strings.TrimSpace(s), no):
+			return false, nil
+		}
+	}
+	return toBool(v)
+}
+
+// boolLabels returns the labels of true and false, "true/false" by default
+func boolLabels(arg string) (string, string) {
+	if yes, no, ok := strings.Cut(arg, "/"); ok {
+		return yes, no
+	}
+	return "true", "false"
+}
+
+// formatPercent formats a percentage: 42 becomes "42 %", so a Slider from 0
+// to 100 shows 0 to 100 %. With arg "ratio" the value is a ratio instead,
+// 0.42 becomes "42 %". The decimals, 0 by default, follow as "1" or
+// "ratio:1".
+func formatPercent(v any, arg string) (any, error) {
+	f, err := toFloat(v)
+	if err != nil {
+		return nil, err
+	}
+	scale, prec, err := percentArg(arg)
+	if err != nil {
+		return nil, err
+	}
+	return strconv.FormatFloat(f*scale, 'f', prec, 64) + " %", nil
+}
+
+// parsePercent parses a percentage back: "42 %" becomes 42, or 0.42 with
+// arg "ratio"
+func parsePercent(v any, arg string) (any, error) {
+	f, err := toFloat(strings.TrimSuffix(strings.TrimSpace(formatValue(v)), "%"))
+	if err != nil {
+		return nil, err
+	}
+	scale, _, err := percentArg(arg)
+	if err != nil {
+		return nil, err
+	}
+	return f / scale, nil
+}
+
+// percentArg parses the argument of the percent converter: the scale of the
+// bound value, 100 for "ratio", and the decimals
+func percentArg(arg string) (float64, int, error) {
+	scale := 1.0
+	if rest, ok := strings.CutPrefix(arg, "ratio"); ok && (rest == "" || rest[0] == ':') {
+		scale, arg = 100, strings.TrimPrefix(rest, ":")
+	}
+	prec, err := decimals(arg, 0)
+	return scale, prec, err
+}
+
+// formatCurrency formats an amount with the currency in arg:
+// "price | currency:EUR" gives "€12.50", unknown codes give "CHF 12.50"
+func formatCurrency(v any, arg string) (any, error) {
+	f, err := toFloat(v)
+	if err != nil {
+		return nil, err
+	}
+
+	prec := 2
+	if arg == "JPY" {
+		prec = 0
+	}
+	amount := strconv.FormatFloat(f, 'f', prec, 64)
+
+	if symbol, ok := currencySymbols[arg]; ok {
+		if f < 0 {
+			return "-" + symbol + amount[1:], nil
+		}
+		return symbol + amount, nil
+	}
+	if arg != "" {
+		return arg + " " + amount, nil
+	}
+	return amount, nil
+}
+
+// parseCurrency parses an amount written with or without the currency
+func parseCurrency(v any, arg string) (any, error) {
+	s := strings.TrimSpace(formatValue(v))
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if symbol, ok := currencySymbols[arg]; ok {
+		s = strings.TrimPrefix(s, symbol)
+	}
+	if arg != "" {
+		s = strings.TrimSpace(strings.TrimPrefix(s, arg))
+	}
+
+	f, err := toFloat(s)
+	if err != nil {
+		return nil, err
+	}
+	if negative {
+		f = -f
+	}
+	return f, nil
+}
+
+// formatPrintf formats the value with the fmt verbs in arg: "temp | format:%.1f °C"
+func formatPrintf(v any, arg string) (any, error) {
+	if arg == "" {
+		return formatValue(v), nil
+	}
+	return fmt.Sprintf(arg, v), nil
+}
+
+// parsePrintf scans a value formatted with formatPrintf, using the type of
+// the first verb in arg
+func parsePrintf(v any, arg string) (any, error) {
+	s := formatValue(v)
+	if arg == "" {
+		return s, nil
+	}
+
+	var err error
+	switch formatVerb(arg) {
+	case 'd':
+		var i int
+		_, err = fmt.Sscanf(s, arg, &i)
+		v = i
+	case 'f', 'F', 'g', 'G', 'e', 'E':
+		var f float64
+		_, err = fmt.Sscanf(s, arg, &f)
+		v = f
+	case 't':
+		var b bool
+		_, err = fmt.Sscanf(s, arg, &b)
+		v = b
+	default:
+		var str string
+		_, err = fmt.Sscanf(s, arg, &str)
+		v = str
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%q does not match format %q", s, arg)
+	}
+	return v, nil
+}
+
+// formatVerb returns the first verb of a fmt format, 0 if there is none
+func formatVerb(format string) byte {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		// Skip flags, width and precision
+		for i++; i < len(format) && strings.IndexByte("+-# 0123456789.", format[i]) >= 0; i++ {
+		}
+		if i < len(format) && format[i] != '%' {
+			return format[i]
+		}
+	}
+	return 0
+}
+
+// formatDate formats a date with the Go layout in arg, "2006-01-02" by default
+func formatDate(v any, arg string) (any, error) {
+	t, err := toTime(v)
+	if err != nil {
+		return nil, err
+	}
+	if arg == "" {
+		arg = defaultDateLayout
+	}
+	return t.Format(arg), nil
+}
+
+// parseDate parses a date written with the Go layout in arg
+func parseDate(v any, arg string) (any, error) {
+	if arg == "" {
+		arg = defaultDateLayout
+	}
+	t, err := time.Parse(arg, strings.TrimSpace(formatValue(v)))
+	if err != nil {
+		return nil, fmt.Errorf("not a date: %q", formatValue(v))
+	}
+	return t, nil
+}
+
+// decimals parses the number of decimals of a converter argument
+func decimals(arg string, def int) (int, error) {
+	if arg == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid decimals %q", arg)
+	}
+	return n, nil
+}
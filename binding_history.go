@@ -0,0 +1,338 @@
+package fylay
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// historyLimit is the number of undo steps kept by the history
+const historyLimit = 200
+
+// historyChange is the change of a bound value. value is the new value;
+// old is the previous one, for the changes recorded in the history.
+type historyChange struct {
+	key   string
+	data  binding.DataItem
+	old   any
+	value any
+}
+
+// historyEntry is an undo step
+type historyEntry struct {
+	changes []historyChange
+	at      time.Time // Last change of the step, for coalescing
+}
+
+// bindingHistory records the changes of the bound values
+type bindingHistory struct {
+	mu       sync.Mutex
+	coalesce time.Duration
+	data     map[string]binding.DataItem // Tracked bindings by key
+	values   map[string]any              // Last known values of the tracked keys
+	ignored  map[string]bool             // Computed keys, derived from others
+
+	undo []historyEntry
+	redo []historyEntry
+
+	suspended int  // Changes applied by the context are recorded by it
+	sealed    bool // The last step cannot be extended by coalescing
+}
+
+// EnableHistory starts recording the changes of the bound values, from
+// widgets or code, so they can be undone with Undo and redone with Redo.
+// Changes of the same key less than coalesce apart are merged into a single
+// step, so typing a word is undone at once; 0 records every change. Keys
+// bound later are recorded too, while computed keys are not. Calling it again
+// only updates coalesce.
+//
+// Layouts can undo and redo with the built-in actions, e.g.
+// <Button onclick="undo" />, unless a callback with the same name is
+// registered.
+func (bc *BindingContext) EnableHistory(coalesce time.Duration) {
+	bc.mu.Lock()
+	if bc.history != nil {
+		bc.history.mu.Lock()
+		bc.history.coalesce = coalesce
+		bc.history.mu.Unlock()
+		bc.mu.Unlock()
+		return
+	}
+
+	h := &bindingHistory{
+		coalesce: coalesce,
+		data:     make(map[string]binding.DataItem),
+		values:   make(map[string]any),
+		ignored:  make(map[string]bool),
+	}
+	for key := range bc.computed {
+		h.ignored[key] = true
+	}
+	bc.history = h
+	bc.mu.Unlock()
+
+	for key, data := range bc.stateBindings() {
+		bc.track(key, data)
+	}
+}
+
+// Undo reverts the last recorded step. It returns false if there is nothing
+// to undo or the history is not enabled.
+func (bc *BindingContext) Undo() bool {
+	h := bc.getHistory()
+	if h == nil {
+		return false
+	}
+
+	h.mu.Lock()
+	entry, ok := pop(&h.undo)
+	if ok {
+		h.redo = append(h.redo, entry)
+		h.sealed = true
+	}
+	h.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	// Changes are reverted in reverse order, restoring the oldest values
+	changes := make([]historyChange, len(entry.changes))
+	for i, c := range entry.changes {
+		changes[len(changes)-1-i] = historyChange{key: c.key, data: c.data, value: c.old}
+	}
+	for _, err := range bc.applyChanges(changes, false) {
+		log.Printf("fylay: undo: %v", err)
+	}
+	return true
+}
+
+// Redo applies again the last undone step. It returns false if there is
+// nothing to redo or the history is not enabled. Any new change clears the
+// steps to redo.
+func (bc *BindingContext) Redo() bool {
+	h := bc.getHistory()
+	if h == nil {
+		return false
+	}
+
+	h.mu.Lock()
+	entry, ok := pop(&h.redo)
+	if ok {
+		h.undo = append(h.undo, entry)
+		h.sealed = true
+	}
+	h.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	for _, err := range bc.applyChanges(entry.changes, false) {
+		log.Printf("fylay: redo: %v", err)
+	}
+	return true
+}
+
+// CanUndo reports whether there is a step to undo
+func (bc *BindingContext) CanUndo() bool {
+	h := bc.getHistory()
+	if h == nil {
+		return false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.undo) > 0
+}
+
+// CanRedo reports whether there is a step to redo
+func (bc *BindingContext) CanRedo() bool {
+	h := bc.getHistory()
+	if h == nil {
+		return false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.redo) > 0
+}
+
+// ClearHistory discards the steps to undo and redo, e.g. after loading a
+// document. Recording goes on.
+func (bc *BindingContext) ClearHistory() {
+	h := bc.getHistory()
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.undo, h.redo = nil, nil
+}
+
+// getHistory returns the history, nil if it is not enabled
+func (bc *BindingContext) getHistory() *bindingHistory {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return bc.history
+}
+
+// track records the changes of a new binding, if the history is enabled.
+// The caller must not hold bc.mu.
+func (bc *BindingContext) track(key string, data binding.DataItem) {
+	h := bc.getHistory()
+	if h == nil {
+		return
+	}
+	for _, leaf := range bindingLeaves(key, data) {
+		h.track(leaf.key, leaf.data)
+	}
+}
+
+// ignoreHistory stops recording the changes of a key, e.g. a computed one
+func (bc *BindingContext) ignoreHistory(key string) {
+	h := bc.getHistory()
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ignored[key] = true
+	delete(h.data, key)
+	delete(h.values, key)
+}
+
+// applyChanges sets bound values without recording each change. With record
+// the changes are recorded as a single step. It returns the errors of the
+// values that could not be set.
+func (bc *BindingContext) applyChanges(changes []historyChange, record bool) []error {
+	h := bc.getHistory()
+	if h != nil {
+		h.mu.Lock()
+		h.suspended++
+		h.mu.Unlock()
+	}
+
+	var errs []error
+	for _, c := range changes {
+		if err := restoreValue(c.data, c.value); err != nil {
+			errs = append(errs, fmt.Errorf("set %s: %w", c.key, err))
+		}
+	}
+
+	if h != nil {
+		h.resume(changes, record)
+	}
+	return errs
+}
+
+// track records the changes of a binding under a key
+func (h *bindingHistory) track(key string, data binding.DataItem) {
+	value, err := dataValue(data)
+	if err != nil {
+		return
+	}
+
+	h.mu.Lock()
+	if h.ignored[key] || h.data[key] == data {
+		h.mu.Unlock()
+		return
+	}
+	h.data[key] = data
+	h.values[key] = copyValue(value)
+	h.mu.Unlock()
+
+	data.AddListener(binding.NewDataListener(func() {
+		h.changed(key, data)
+	}))
+}
+
+// changed records the change of a tracked binding, merging it into the last
+// step if it changes the same key within the coalesce interval
+func (h *bindingHistory) changed(key string, data binding.DataItem) {
+	value, err := dataValue(data)
+	if err != nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Bindings replaced under the same key are no longer tracked
+	if h.suspended > 0 || h.data[key] != data {
+		return
+	}
+	old := h.values[key]
+	if reflect.DeepEqual(old, value) {
+		return
+	}
+	value = copyValue(value)
+	h.values[key] = value
+	h.redo = nil
+
+	now := time.Now()
+	if n := len(h.undo); n > 0 && !h.sealed && h.coalesce > 0 {
+		last := &h.undo[n-1]
+		if len(last.changes) == 1 && last.changes[0].key == key && now.Sub(last.at) < h.coalesce {
+			last.changes[0].value = value
+			last.at = now
+			return
+		}
+	}
+	h.push(historyEntry{
+		changes: []historyChange{{key: key, data: data, old: old, value: value}},
+		at:      now,
+	})
+}
+
+// resume records again after applying changes, updating the known values.
+// With record the applied changes become a new step.
+func (h *bindingHistory) resume(changes []historyChange, record bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.suspended--
+
+	var step []historyChange
+	for _, c := range changes {
+		if h.data[c.key] != c.data {
+			continue
+		}
+		value, err := dataValue(c.data)
+		if err != nil {
+			continue
+		}
+		old := h.values[c.key]
+		value = copyValue(value)
+		h.values[c.key] = value
+		if record && !reflect.DeepEqual(old, value) {
+			step = append(step, historyChange{key: c.key, data: c.data, old: old, value: value})
+		}
+	}
+
+	if len(step) > 0 {
+		h.redo = nil
+		h.push(historyEntry{changes: step, at: time.Now()})
+		h.sealed = true
+	}
+}
+
+// push adds an undo step, dropping the oldest beyond historyLimit. The
+// caller must hold h.mu.
+func (h *bindingHistory) push(entry historyEntry) {
+	h.undo = append(h.undo, entry)
+	if len(h.undo) > historyLimit {
+		h.undo = append(h.undo[:0], h.undo[len(h.undo)-historyLimit:]...)
+	}
+	h.sealed = false
+}
+
+// pop removes and returns the last step of a stack
+func pop(stack *[]historyEntry) (historyEntry, bool) {
+	n := len(*stack)
+	if n == 0 {
+		return historyEntry{}, false
+	}
+	entry := (*stack)[n-1]
+	*stack = (*stack)[:n-1]
+	return entry, true
+}
@@ -0,0 +1,168 @@
+package fylay
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// prefsPrefix marks bind keys stored in the app preferences, e.g.
+// bind="prefs:theme" is stored under the preference theme
+const prefsPrefix = "prefs:"
+
+// Persist stores the binding of key in the app preferences under the same
+// name, so its value survives restarts. If key is already bound, the stored
+// value, if any, replaces the current one and every change is saved;
+// otherwise the binding created for key later, by a widget or a Bind call,
+// is backed by the preference, whose stored value wins over the one passed
+// to Bind. Only string, int, float, bool and string list values can be
+// persisted. The bind="prefs:name" syntax persists a key without calling
+// Persist. Persisting a key again has no effect.
+//
+//	ctx.BindString("theme", "light")
+//	ctx.Persist("theme")
+func (bc *BindingContext) Persist(key string) error {
+	prefs, err := appPreferences()
+	if err != nil {
+		return err
+	}
+
+	// Keys are persisted once, later calls would save them twice
+	bc.mu.Lock()
+	if bc.persisted[key] {
+		bc.mu.Unlock()
+		return nil
+	}
+	bc.persisted[key] = true
+	data := bc.resolveLocked(key)
+	bc.mu.Unlock()
+	if data == nil {
+		return nil
+	}
+
+	if err := persistBinding(prefs, key, data); err != nil {
+		bc.mu.Lock()
+		delete(bc.persisted, key)
+		bc.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// persistBinding restores the stored value of a bound key, if any, and saves
+// every change of the binding in the preferences
+func persistBinding(prefs fyne.Preferences, key string, data binding.DataItem) error {
+	value, err := dataValue(data)
+	if err != nil {
+		return err
+	}
+	pref, stored, err := bindPreference(prefs, key, value)
+	if err != nil {
+		return fmt.Errorf("persist %s: %w", key, err)
+	}
+
+	if stored {
+		prefValue, err := dataValue(pref)
+		if err != nil {
+			return err
+		}
+		if err := setDataValue(data, prefValue); err != nil {
+			return fmt.Errorf("persist %s: %w", key, err)
+		}
+	}
+
+	// The listener also saves the current value right away
+	data.AddListener(binding.NewDataListener(func() {
+		if v, err := dataValue(data); err == nil {
+			if err := setDataValue(pref, v); err != nil {
+				log.Printf("fylay: persist %s: %v", key, err)
+			}
+		}
+	}))
+	return nil
+}
+
+// preferenceNameLocked returns the preference storing a key, if the key is
+// persisted. The caller must hold bc.mu.
+func (bc *BindingContext) preferenceNameLocked(key string) (string, bool) {
+	if name, ok := strings.CutPrefix(key, prefsPrefix); ok {
+		return name, true
+	}
+	return key, bc.persisted[key]
+}
+
+// newBindingLocked stores a new binding for key holding value. Persisted
+// keys get a binding of the same type backed by the app preferences, which
+// keeps the stored value if the preference is already set. The caller must
+// hold bc.mu.
+func newBindingLocked[T binding.DataItem](bc *BindingContext, key string, data T, value any) T {
+	// No listeners yet, the value can be set under the lock
+	_ = setDataValue(data, value) //nolint:errcheck // Same type as the binding
+	if name, ok := bc.preferenceNameLocked(key); ok {
+		if pref, ok := preferenceBinding(name, data).(T); ok {
+			data = pref
+		}
+	}
+	bc.data[key] = data
+	return data
+}
+
+// preferenceBinding returns a binding backed by the preference name, of the
+// type of the new binding data. The value of data is stored if the preference
+// is not set yet. data is returned as is if it cannot be persisted.
+func preferenceBinding(name string, data binding.DataItem) binding.DataItem {
+	prefs, err := appPreferences()
+	if err == nil {
+		var value any
+		if value, err = dataValue(data); err == nil {
+			var pref binding.DataItem
+			var stored bool
+			if pref, stored, err = bindPreference(prefs, name, value); err == nil {
+				if !stored {
+					_ = setDataValue(pref, value) //nolint:errcheck // Same type as the preference
+				}
+				return pref
+			}
+		}
+	}
+
+	log.Printf("fylay: cannot persist %s: %v", name, err)
+	return data
+}
+
+// bindPreference returns Fyne's preference binding for a value type and
+// whether the preference is already set
+func bindPreference(prefs fyne.Preferences, name string, value any) (binding.DataItem, bool, error) {
+	switch value.(type) {
+	case string:
+		stored := prefs.StringWithFallback(name, "a") == prefs.StringWithFallback(name, "b")
+		return binding.BindPreferenceString(name, prefs), stored, nil
+	case int:
+		stored := prefs.IntWithFallback(name, 0) == prefs.IntWithFallback(name, 1)
+		return binding.BindPreferenceInt(name, prefs), stored, nil
+	case float64:
+		stored := prefs.FloatWithFallback(name, 0) == prefs.FloatWithFallback(name, 1)
+		return binding.BindPreferenceFloat(name, prefs), stored, nil
+	case bool:
+		stored := prefs.BoolWithFallback(name, false) == prefs.BoolWithFallback(name, true)
+		return binding.BindPreferenceBool(name, prefs), stored, nil
+	case []string:
+		stored := (prefs.StringListWithFallback(name, nil) == nil) == (prefs.StringListWithFallback(name, []string{}) == nil)
+		return binding.BindPreferenceStringList(name, prefs), stored, nil
+	default:
+		return nil, false, fmt.Errorf("cannot persist %T values", value)
+	}
+}
+
+// appPreferences returns the preferences of the running app
+func appPreferences() (fyne.Preferences, error) {
+	app := fyne.CurrentApp()
+	if app == nil {
+		return nil, errors.New("no Fyne app is running")
+	}
+	return app.Preferences(), nil
+}
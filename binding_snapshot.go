@@ -0,0 +1,211 @@
+package fylay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// Snapshot is the state of a binding context: the value of each bound key.
+// Bound structs and maps are held as a map of their field values. Snapshots
+// of string, number, bool and list values can be encoded as JSON.
+type Snapshot map[string]any
+
+// bindingLeaf is a binding holding a value, with its key. The fields of
+// bound structs and maps are leaves keyed by their nested path.
+type bindingLeaf struct {
+	key  string
+	data binding.DataItem
+}
+
+// Snapshot returns the current value of every bound key. Computed keys and
+// the items of list rows are left out, as they are derived from other keys.
+func (bc *BindingContext) Snapshot() Snapshot {
+	snapshot := make(Snapshot)
+	for key, data := range bc.stateBindings() {
+		m, ok := data.(binding.DataMap)
+		if !ok {
+			if value, err := dataValue(data); err == nil {
+				snapshot[key] = copyValue(value)
+			}
+			continue
+		}
+
+		fields := make(map[string]any)
+		for _, leaf := range mapLeaves(key, m) {
+			if value, err := dataValue(leaf.data); err == nil {
+				fields[strings.TrimPrefix(leaf.key, key+".")] = copyValue(value)
+			}
+		}
+		snapshot[key] = fields
+	}
+	return snapshot
+}
+
+// Restore sets the bound keys to the values of a snapshot, converting them
+// to the type of each binding. Keys that are not bound yet are bound to a
+// binding of the type of their value; keys of the context missing from the
+// snapshot are left as they are. With the history enabled, the restore is
+// a single undo step. Values that cannot be set are reported together after
+// setting all the others.
+func (bc *BindingContext) Restore(snapshot Snapshot) error {
+	var errs []error
+	var changes []historyChange
+
+	keys := make([]string, 0, len(snapshot))
+	for key := range snapshot {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := snapshot[key]
+		data := bc.getOrCreate(key, func() binding.DataItem {
+			return newValueBinding(value)
+		})
+
+		m, ok := data.(binding.DataMap)
+		if !ok {
+			changes = append(changes, historyChange{key: key, data: data, value: value})
+			continue
+		}
+
+		fields, ok := value.(map[string]any)
+		if !ok {
+			errs = append(errs, fmt.Errorf("restore %s: cannot set %T to a struct or map", key, value))
+			continue
+		}
+		for _, leaf := range mapLeaves(key, m) {
+			if v, ok := fields[strings.TrimPrefix(leaf.key, key+".")]; ok {
+				changes = append(changes, historyChange{key: leaf.key, data: leaf.data, value: v})
+			}
+		}
+	}
+
+	errs = append(errs, bc.applyChanges(changes, true)...)
+	return errors.Join(errs...)
+}
+
+// ExportJSON encodes a snapshot of the context as JSON
+func (bc *BindingContext) ExportJSON() ([]byte, error) {
+	return json.Marshal(bc.Snapshot())
+}
+
+// ImportJSON restores a snapshot encoded as JSON, e.g. by ExportJSON.
+// JSON lists of strings are restored as string lists.
+func (bc *BindingContext) ImportJSON(data []byte) error {
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return fmt.Errorf("import bindings: %w", err)
+	}
+	for key, value := range snapshot {
+		snapshot[key] = stringLists(value)
+	}
+	return bc.Restore(snapshot)
+}
+
+// stateBindings returns the bindings holding the state of the context, by
+// key: computed keys are left out
+func (bc *BindingContext) stateBindings() map[string]binding.DataItem {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	state := make(map[string]binding.DataItem, len(bc.data))
+	for key, data := range bc.data {
+		if _, ok := bc.computed[key]; ok {
+			continue
+		}
+		state[key] = data
+	}
+	return state
+}
+
+// bindingLeaves returns the bindings holding the values of a bound key: the
+// key itself, or the fields of a bound struct or map
+func bindingLeaves(key string, data binding.DataItem) []bindingLeaf {
+	if m, ok := data.(binding.DataMap); ok {
+		return mapLeaves(key, m)
+	}
+	return []bindingLeaf{{key: key, data: data}}
+}
+
+// mapLeaves returns the fields of a bound struct or map, in key order
+func mapLeaves(key string, m binding.DataMap) []bindingLeaf {
+	fields := m.Keys()
+	sort.Strings(fields)
+
+	result := make([]bindingLeaf, 0, len(fields))
+	for _, field := range fields {
+		item, err := m.GetItem(field)
+		if err != nil {
+			continue
+		}
+		result = append(result, bindingLeaf{key: key + "." + field, data: mapItem(m, field, item)})
+	}
+	return result
+}
+
+// restoreValue sets a binding to a restored value, converting lists between
+// string and untyped lists
+func restoreValue(data binding.DataItem, v any) error {
+	switch data.(type) {
+	case binding.StringList:
+		if items, ok := v.([]any); ok {
+			list := make([]string, len(items))
+			for i, item := range items {
+				list[i] = formatValue(item)
+			}
+			v = list
+		}
+	case binding.UntypedList:
+		if list, ok := v.([]string); ok {
+			items := make([]any, len(list))
+			for i, s := range list {
+				items[i] = s
+			}
+			v = items
+		}
+	}
+	return setDataValue(data, v)
+}
+
+// copyValue copies list values, so later changes to the bound list do not
+// alter a snapshot
+func copyValue(v any) any {
+	switch v := v.(type) {
+	case []string:
+		return append([]string(nil), v...)
+	case []any:
+		return append([]any(nil), v...)
+	default:
+		return v
+	}
+}
+
+// stringLists converts decoded JSON lists made only of strings to string
+// lists, in nested objects too
+func stringLists(v any) any {
+	switch v := v.(type) {
+	case []any:
+		list := make([]string, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return v
+			}
+			list[i] = s
+		}
+		return list
+	case map[string]any:
+		for key, value := range v {
+			v[key] = stringLists(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,229 @@
+package fylay
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// BindStruct binds the exported fields of a struct to a key, so they can be
+// addressed as nested paths (e.g. bind="user.Name"). s must be a pointer to
+// a struct: widget changes are written back into its fields. Call Reload on
+// the returned binding after changing the struct directly.
+func (bc *BindingContext) BindStruct(key string, s any) binding.Struct {
+	data := binding.BindStruct(s)
+
+	bc.mu.Lock()
+	bc.data[key] = data
+	bc.mu.Unlock()
+
+	bc.stored(key, data)
+	return data
+}
+
+// BindMap binds the entries of a map to a key, so they can be addressed as
+// nested paths (e.g. bind="settings.theme"). Widget changes are written back
+// into the map. Call Reload on the returned binding after changing the map
+// directly.
+func (bc *BindingContext) BindMap(key string, m *map[string]any) binding.ExternalUntypedMap {
+	if m == nil {
+		m = &map[string]any{}
+	}
+	bm := newBoundMap(m)
+
+	bc.mu.Lock()
+	bc.data[key] = bm
+	bc.mu.Unlock()
+
+	bc.stored(key, bm)
+	return bm
+}
+
+// resolveLocked returns the binding for a key, following nested paths into
+// bound structs and maps. The caller must hold bc.mu.
+func (bc *BindingContext) resolveLocked(key string) binding.DataItem {
+	if data, ok := bc.rootLocked(key); ok {
+		return data
+	}
+
+	// Try the longest bound prefix first, keys may contain dots themselves
+	parts := strings.Split(key, ".")
+	for i := len(parts) - 1; i > 0; i-- {
+		data, ok := bc.rootLocked(strings.Join(parts[:i], "."))
+		if !ok {
+			continue
+		}
+
+		for _, field := range parts[i:] {
+			m, ok := data.(binding.DataMap)
+			if !ok {
+				return nil
+			}
+			item, err := m.GetItem(field)
+			if err != nil {
+				return nil
+			}
+			data = mapItem(m, field, item)
+		}
+		return data
+	}
+
+	return nil
+}
+
+// rootLocked returns the binding bound to a key, or the scope of the row
+// registered under it. The caller must hold bc.mu.
+func (bc *BindingContext) rootLocked(key string) (binding.DataItem, bool) {
+	if data, ok := bc.data[key]; ok {
+		return data, true
+	}
+	if row, ok := bc.rows[key]; ok {
+		return rowValue{row: row}, true
+	}
+	return nil, false
+}
+
+// boundMap is a map bound with BindMap. Entries are read from a copy of the
+// map guarded by its own lock: the map itself is written by Fyne under the
+// lock of the map binding, which is still held while listeners are notified.
+type boundMap struct {
+	binding.ExternalUntypedMap
+	val *map[string]any
+
+	mu     sync.RWMutex
+	values map[string]any // Copy of the map, updated before each change
+}
+
+// newBoundMap binds a map
+func newBoundMap(m *map[string]any) *boundMap {
+	return &boundMap{
+		ExternalUntypedMap: binding.BindUntypedMap(m),
+		val:                m,
+		values:             copyMap(*m),
+	}
+}
+
+// Set replaces the map
+func (bm *boundMap) Set(m map[string]any) error {
+	bm.mu.Lock()
+	bm.values = copyMap(m)
+	bm.mu.Unlock()
+	return bm.ExternalUntypedMap.Set(m)
+}
+
+// SetValue sets an entry of the map
+func (bm *boundMap) SetValue(key string, v any) error {
+	bm.mu.Lock()
+	bm.values[key] = v
+	bm.mu.Unlock()
+	return bm.ExternalUntypedMap.SetValue(key, v)
+}
+
+// GetValue returns an entry of the map
+func (bm *boundMap) GetValue(key string) (any, error) {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+	v, ok := bm.values[key]
+	if !ok {
+		return nil, fmt.Errorf("key not found: %s", key)
+	}
+	return v, nil
+}
+
+// Delete removes an entry of the map
+func (bm *boundMap) Delete(key string) {
+	bm.mu.Lock()
+	delete(bm.values, key)
+	bm.mu.Unlock()
+	bm.ExternalUntypedMap.Delete(key)
+}
+
+// Reload reads the map again after it was changed directly
+func (bm *boundMap) Reload() error {
+	bm.mu.Lock()
+	bm.values = copyMap(*bm.val)
+	bm.mu.Unlock()
+	return bm.ExternalUntypedMap.Reload()
+}
+
+// copyMap copies a map, returning an empty map for a nil one
+func copyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return make(map[string]any)
+	}
+	return maps.Clone(m)
+}
+
+// mapItem returns an item of a bound map as a usable binding. Struct fields
+// are already typed bindings, while map entries only expose their listeners
+// and are adapted to an untyped binding.
+func mapItem(m binding.DataMap, key string, item binding.DataItem) binding.DataItem {
+	if bm, ok := m.(*boundMap); ok {
+		return mapEntry{DataItem: item, m: bm, key: key}
+	}
+	return item
+}
+
+// mapEntry is an entry of a map bound with BindMap
+type mapEntry struct {
+	binding.DataItem
+	m   *boundMap
+	key string
+}
+
+// Get returns the value of the entry
+func (e mapEntry) Get() (any, error) {
+	return e.m.GetValue(e.key)
+}
+
+// Set sets the value of the entry
+func (e mapEntry) Set(v any) error {
+	return e.m.SetValue(e.key, v)
+}
+
+// untypedString adapts an untyped binding, such as a map entry, to a string
+// binding. Set parses the string according to the type of the current value.
+type untypedString struct {
+	binding.Untyped
+}
+
+// Get returns the value formatted as a string
+func (u untypedString) Get() (string, error) {
+	v, err := u.Untyped.Get()
+	if err != nil || v == nil {
+		return "", err
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+// Set parses the string and sets the value
+func (u untypedString) Set(s string) error {
+	current, err := u.Untyped.Get()
+	if err != nil {
+		return err
+	}
+
+	var v any
+	switch current.(type) {
+	case bool:
+		v, err = strconv.ParseBool(s)
+	case int:
+		v, err = strconv.Atoi(s)
+	case float64:
+		v, err = strconv.ParseFloat(s, 64)
+	default:
+		v = s
+	}
+	if err != nil {
+		return err
+	}
+
+	return u.Untyped.Set(v)
+}
@@ -0,0 +1,1087 @@
+package fylay
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+// writeTestPNG writes a 1x1 PNG file and returns its path
+func writeTestPNG(t *testing.T, name string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(path) //nolint:gosec // Test file in temp dir
+	if err != nil {
+		t.Fatalf("Failed to create image: %v", err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatalf("Failed to encode image: %v", err)
+	}
+	return path
+}
+
+func TestBindAttribute(t *testing.T) {
+	_ = test.NewApp()
+
+	first := writeTestPNG(t, "first.png")
+	second := writeTestPNG(t, "second.png")
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<Entry id="name" bind="user.name" />
+		<Label id="greeting" bind="greeting">Hello</Label>
+		<Label id="count" bind="count" />
+		<Text id="title" bind="title">Untitled</Text>
+		<Image id="avatar" bind="avatar" src="` + first + `" />
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	ctx := builder.GetBindingContext()
+	ctx.BindString("user.name", "Ada")
+	ctx.BindInt("count", 3)
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	// Entry: existing values are kept and changes flow both ways
+	entry := builder.GetWidget("name").(*widget.Entry)
+	if entry.Text != "Ada" {
+		t.Errorf("Expected entry to show the bound value, got %q", entry.Text)
+	}
+	entry.SetText("Ada!")
+	if v, _ := ctx.GetString("user.name"); v != "Ada!" {
+		t.Errorf("Expected entry changes to update the binding, got %q", v)
+	}
+	ctx.BindString("user.name", "Grace")
+	if entry.Text != "Grace" {
+		t.Errorf("Expected binding to update the entry, got %q", entry.Text)
+	}
+
+	// Label: the content is the initial value, other types are converted
+	if v, _ := ctx.GetString("greeting"); v != "Hello" {
+		t.Errorf("Expected label content as initial value, got %q", v)
+	}
+	ctx.BindString("greeting", "Hi")
+	if label := builder.GetWidget("greeting").(*widget.Label); label.Text != "Hi" {
+		t.Errorf("Expected label to follow the binding, got %q", label.Text)
+	}
+	ctx.BindInt("count", 4)
+	if label := builder.GetWidget("count").(*widget.Label); label.Text != "4" {
+		t.Errorf("Expected int binding shown as text, got %q", label.Text)
+	}
+
+	// Text: one-way
+	ctx.BindString("title", "Report")
+	if txt := builder.GetElement("title").(*canvas.Text); txt.Text != "Report" {
+		t.Errorf("Expected text to follow the binding, got %q", txt.Text)
+	}
+
+	// Image: src is the initial value of the bound URL
+	img := builder.GetWidget("avatar").(*ImageWidget)
+	if img.GetSource() != first || img.File == "" {
+		t.Errorf("Expected image loaded from %s, got %s", first, img.File)
+	}
+	ctx.BindString("avatar", second)
+	if img.GetSource() != second || filepath.Base(img.File) != "second.png" {
+		t.Errorf("Expected image reloaded from %s, got %s", second, img.File)
+	}
+}
+
+func TestBindAttributeStoppedOnRebuild(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<Text id="title" bind="title">Draft</Text>
+		<Image id="logo" bind="logo" />
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	ctx := builder.GetBindingContext()
+	first := writeTestPNG(t, "first.png")
+	ctx.BindString("logo", first)
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+	oldTitle := builder.GetElement("title").(*canvas.Text)
+	oldLogo := builder.GetWidget("logo").(*ImageWidget)
+
+	if _, err := builder.Rebuild(layout); err != nil {
+		t.Fatalf("Failed to rebuild: %v", err)
+	}
+	second := writeTestPNG(t, "second.png")
+	ctx.BindString("title", "Final")
+	ctx.BindString("logo", second)
+
+	// The replaced text and image no longer follow the bindings
+	if oldTitle.Text != "Draft" {
+		t.Errorf("Expected the replaced text to stop following title, got %q", oldTitle.Text)
+	}
+	if oldLogo.GetSource() != first {
+		t.Errorf("Expected the replaced image not to load %q", oldLogo.GetSource())
+	}
+	if got := builder.GetElement("title").(*canvas.Text).Text; got != "Final" {
+		t.Errorf("Expected the rebuilt text to follow title, got %q", got)
+	}
+	if got := builder.GetWidget("logo").(*ImageWidget).GetSource(); got != second {
+		t.Errorf("Expected the rebuilt image to follow logo, got %q", got)
+	}
+}
+
+func TestBindGroups(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<RadioGroup id="size" bind="size" onchange="onSize">
+			<Radio>S</Radio>
+			<Radio selected="true">M</Radio>
+			<Radio>L</Radio>
+		</RadioGroup>
+		<CheckGroup id="tags" bind="tags" onchange="onTags">
+			<Check selected="true">news</Check>
+			<Check value="tech">Technology</Check>
+			<Check>sport</Check>
+		</CheckGroup>
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	var events []string
+	record := func(ctx *EventContext) { events = append(events, ctx.EventName+"="+ctx.Value) }
+	builder.On("onSize", record)
+	builder.On("onTags", record)
+
+	ctx := builder.GetBindingContext()
+	radio := builder.GetWidget("size").(*widget.RadioGroup)
+	group := builder.GetWidget("tags").(*widget.CheckGroup)
+
+	// Initial selections become the binding values
+	if v, _ := ctx.GetString("size"); v != "M" {
+		t.Errorf("Expected size binding M, got %q", v)
+	}
+	if v, _ := ctx.GetStringList("tags"); strings.Join(v, ",") != "news" {
+		t.Errorf("Expected tags binding [news], got %v", v)
+	}
+
+	// Widget to binding
+	radio.SetSelected("L")
+	group.SetSelected([]string{"news", "tech"})
+	if v, _ := ctx.GetString("size"); v != "L" {
+		t.Errorf("Expected size binding L, got %q", v)
+	}
+	if v, _ := ctx.GetStringList("tags"); strings.Join(v, ",") != "news,tech" {
+		t.Errorf("Expected tags binding [news tech], got %v", v)
+	}
+
+	// Binding to widget
+	ctx.BindString("size", "S")
+	ctx.BindStringList("tags", []string{"sport"})
+	if radio.Selected != "S" {
+		t.Errorf("Expected radio selection S, got %q", radio.Selected)
+	}
+	if strings.Join(group.Selected, ",") != "sport" {
+		t.Errorf("Expected group selection [sport], got %v", group.Selected)
+	}
+
+	want := "onSize=L,onTags=news,tech,onSize=S,onTags=sport"
+	if got := strings.Join(events, ","); got != want {
+		t.Errorf("Unexpected events %q, want %q", got, want)
+	}
+}
+
+func TestBindGroupsStoppedOnRebuild(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<RadioGroup id="size" bind="size" onchange="changed">
+			<Radio>S</Radio>
+			<Radio selected="true">M</Radio>
+		</RadioGroup>
+		<CheckGroup id="tags" bind="tags" onchange="changed">
+			<Check selected="true">news</Check>
+			<Check>sport</Check>
+		</CheckGroup>
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	ctx := builder.GetBindingContext()
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+	oldSize := builder.GetWidget("size").(*widget.RadioGroup)
+	oldTags := builder.GetWidget("tags").(*widget.CheckGroup)
+
+	if _, err := builder.Rebuild(layout); err != nil {
+		t.Fatalf("Failed to rebuild: %v", err)
+	}
+	var changed []string
+	builder.On("changed", func(ev *EventContext) {
+		changed = append(changed, ev.TargetID)
+	})
+	ctx.BindString("size", "S")
+	ctx.BindStringList("tags", []string{"news", "sport"})
+
+	// The replaced groups no longer follow the bindings nor fire onchange
+	if oldSize.Selected != "M" || !slices.Equal(oldTags.Selected, []string{"news"}) {
+		t.Errorf("Expected the replaced groups to keep their selection, got %q %v", oldSize.Selected, oldTags.Selected)
+	}
+	if got := strings.Join(changed, ","); got != "size,tags" {
+		t.Errorf("Expected onchange from the rebuilt groups only, got %q", got)
+	}
+	if builder.GetWidget("size").(*widget.RadioGroup).Selected != "S" {
+		t.Error("Expected the rebuilt radio group to follow size")
+	}
+	if !slices.Equal(builder.GetWidget("tags").(*widget.CheckGroup).Selected, []string{"news", "sport"}) {
+		t.Error("Expected the rebuilt check group to follow tags")
+	}
+}
+func TestBindStructAndMap(t *testing.T) {
+	_ = test.NewApp()
+
+	type user struct {
+		Name  string
+		Age   int
+		Admin bool
+	}
+	u := &user{Name: "Ada", Age: 36, Admin: true}
+	settings := map[string]any{"theme": "dark", "volume": 0.5, "notify": false}
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<Entry id="name" bind="user . Name" />
+		<Slider id="age" bind="user.Age" min="0" max="120" value="18" />
+		<Checkbox id="admin" bind="user.Admin" />
+		<Label id="theme" bind="settings.theme">light</Label>
+		<Entry id="volume" bind="settings.volume" />
+		<Checkbox id="notify" bind="settings.notify" />
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	ctx := builder.GetBindingContext()
+	userData := ctx.BindStruct("user", u)
+	ctx.BindMap("settings", &settings)
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	name := builder.GetWidget("name").(*widget.Entry)
+	age := builder.GetWidget("age").(*widget.Slider)
+	admin := builder.GetWidget("admin").(*widget.Check)
+	theme := builder.GetWidget("theme").(*widget.Label)
+	volume := builder.GetWidget("volume").(*widget.Entry)
+	notify := builder.GetWidget("notify").(*widget.Check)
+
+	// The bound values win over the layout defaults
+	if name.Text != "Ada" || age.Value != 36 || !admin.Checked {
+		t.Errorf("Expected struct values in widgets, got %q %v %v", name.Text, age.Value, admin.Checked)
+	}
+	if theme.Text != "dark" || volume.Text != "0.5" || notify.Checked {
+		t.Errorf("Expected map values in widgets, got %q %q %v", theme.Text, volume.Text, notify.Checked)
+	}
+
+	// Widget changes are written back into the struct and the map
+	name.SetText("Grace")
+	age.SetValue(40)
+	admin.SetChecked(false)
+	volume.SetText("0.8")
+	notify.SetChecked(true)
+
+	if u.Name != "Grace" || u.Age != 40 || u.Admin {
+		t.Errorf("Expected struct to be updated, got %+v", *u)
+	}
+	if settings["volume"] != 0.8 || settings["notify"] != true {
+		t.Errorf("Expected map to be updated, got %v", settings)
+	}
+
+	// Nested paths work with the typed getters and setters
+	if v, err := ctx.GetString("user.Name"); err != nil || v != "Grace" {
+		t.Errorf("GetString(user.Name) = %q, %v", v, err)
+	}
+	ctx.BindString("user.Name", "Linus")
+	if u.Name != "Linus" || name.Text != "Linus" {
+		t.Errorf("Expected BindString on a nested path to update struct and entry, got %q %q", u.Name, name.Text)
+	}
+
+	// Direct struct changes are picked up after Reload
+	u.Age = 50
+	if err := userData.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if age.Value != 50 {
+		t.Errorf("Expected slider to follow reloaded struct, got %v", age.Value)
+	}
+}
+
+func TestParseBindAttribute(t *testing.T) {
+	tests := map[string]string{
+		"name":                 "name",
+		"  user.Name ":         "user.Name",
+		"user . Address.City":  "user.Address.City",
+		"price | currency:EUR": "price",
+	}
+	for in, want := range tests {
+		if got := ParseBindAttribute(in); got != want {
+			t.Errorf("ParseBindAttribute(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestBindConverters(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<Slider id="slider" bind="volume" />
+		<Label id="volume" bind="volume | percent" />
+		<Label id="share" bind="share | percent:ratio" />
+		<Label id="price" bind="price | currency:EUR" />
+		<Label id="chain" bind="price | float:1 | format:[%s]" />
+		<Entry id="count" bind="count | int" />
+		<Entry id="temp" bind="temp | format:%.1f" />
+		<Label id="active" bind="active | bool:On/Off" />
+		<Label id="due" bind="due | date:02/01/2006" />
+		<Entry id="dueEdit" bind="due | date:02/01/2006" />
+		<Entry id="stamp" bind="stamp | date" />
+		<Entry id="unix" bind="unix | date" />
+		<Label id="name" bind="name | upper" />
+		<Label id="unknown" bind="name | nope">fallback</Label>
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	ctx := builder.GetBindingContext()
+	ctx.BindFloat("share", 0.42)
+	ctx.BindFloat("price", 12.5)
+	ctx.BindInt("count", 7)
+	ctx.BindFloat("temp", 21.34)
+	ctx.BindBool("active", true)
+	ctx.BindString("due", "2026-10-18")
+	ctx.BindString("stamp", "2024-05-01T10:30:00Z")
+	ctx.BindInt("unix", 0)
+	ctx.BindString("name", "Ada")
+	ctx.RegisterConverter("upper", func(v any, _ string) (any, error) {
+		return strings.ToUpper(formatValue(v)), nil
+	}, nil)
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	label := func(id string) string {
+		return builder.GetWidget(id).(*widget.Label).Text
+	}
+
+	expected := map[string]string{
+		"volume":  "0 %",
+		"share":   "42 %",
+		"price":   "€12.50",
+		"chain":   "[12.5]",
+		"active":  "On",
+		"due":     "18/10/2026",
+		"name":    "ADA",
+		"unknown": "fallback",
+	}
+	for id, want := range expected {
+		if got := label(id); got != want {
+			t.Errorf("Label %s = %q, want %q", id, got, want)
+		}
+	}
+
+	// The converted label follows a default slider, from 0 to 100
+	builder.GetWidget("slider").(*widget.Slider).SetValue(42)
+	if got := label("volume"); got != "42 %" {
+		t.Errorf("Expected percent label to follow the slider, got %q", got)
+	}
+
+	// Two-way converters write back the source type
+	count := builder.GetWidget("count").(*widget.Entry)
+	if count.Text != "7" {
+		t.Errorf("Expected count entry to show 7, got %q", count.Text)
+	}
+	count.SetText("9")
+	if v, err := ctx.GetInt("count"); err != nil || v != 9 {
+		t.Errorf("Expected count to be 9, got %v, %v", v, err)
+	}
+
+	temp := builder.GetWidget("temp").(*widget.Entry)
+	if temp.Text != "21.3" {
+		t.Errorf("Expected formatted temperature, got %q", temp.Text)
+	}
+	temp.SetText("18.5")
+	if v, err := ctx.GetFloat("temp"); err != nil || v != 18.5 {
+		t.Errorf("Expected temp to be 18.5, got %v, %v", v, err)
+	}
+
+	// Dates are written back in the form of the bound value
+	builder.GetWidget("dueEdit").(*widget.Entry).SetText("01/05/2024")
+	if v, _ := ctx.GetString("due"); v != "2024-05-01" {
+		t.Errorf("Expected the date layout of the source, got %q", v)
+	}
+	builder.GetWidget("stamp").(*widget.Entry).SetText("2024-05-02")
+	if v, _ := ctx.GetString("stamp"); v != "2024-05-02T00:00:00Z" {
+		t.Errorf("Expected an RFC 3339 source to stay RFC 3339, got %q", v)
+	}
+	builder.GetWidget("unix").(*widget.Entry).SetText("1970-01-02")
+	if v, _ := ctx.GetInt("unix"); v != 86400 {
+		t.Errorf("Expected Unix seconds, got %d", v)
+	}
+}
+
+func TestConverterRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		value   any
+		display string
+	}{
+		{"int", "", 42, "42"},
+		{"float", "2", 3.25, "3.25"},
+		{"bool", "yes/no", false, "no"},
+		{"percent", "", 42.0, "42 %"},
+		{"percent", "1", 12.5, "12.5 %"},
+		{"percent", "ratio", 0.42, "42 %"},
+		{"percent", "ratio:1", 0.125, "12.5 %"},
+		{"currency", "USD", -4.5, "-$4.50"},
+		{"currency", "CHF", 10.0, "CHF 10.00"},
+		{"format", "%d items", 3, "3 items"},
+		{"date", "", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), "2024-05-01"},
+		{"date", "02/01/2006", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), "01/05/2024"},
+	}
+
+	for _, tt := range tests {
+		conv := builtinConverters[tt.name]
+		shown, err := conv.to(tt.value, tt.arg)
+		if err != nil || formatValue(shown) != tt.display {
+			t.Errorf("%s:%s to(%v) = %v, %v, want %q", tt.name, tt.arg, tt.value, shown, err, tt.display)
+			continue
+		}
+
+		back, err := conv.from(tt.display, tt.arg)
+		if err != nil {
+			t.Errorf("%s:%s from(%q) failed: %v", tt.name, tt.arg, tt.display, err)
+			continue
+		}
+		if formatValue(back) != formatValue(tt.value) {
+			t.Errorf("%s:%s from(%q) = %v, want %v", tt.name, tt.arg, tt.display, back, tt.value)
+		}
+	}
+}
+
+func TestComputed(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<Label id="full" bind="fullName" />
+		<Computed key="fullName" expr="first + ' ' + last" />
+		<Computed key="canSave" expr="first != '' &amp;&amp; last != ''" />
+		<Entry id="first" bind="first" />
+		<Entry id="last" bind="last" />
+		<Label id="total" bind="total | currency:EUR" />
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	ctx := builder.GetBindingContext()
+	ctx.BindInt("qty", 2)
+	ctx.BindFloat("unit", 4.5)
+	ctx.Computed("total", []string{"qty", "unit"}, func(v []any) any {
+		qty, _ := v[0].(int)
+		unit, _ := v[1].(float64)
+		return float64(qty) * unit
+	})
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	full := builder.GetWidget("full").(*widget.Label)
+	total := builder.GetWidget("total").(*widget.Label)
+	if total.Text != "€9.00" {
+		t.Errorf("Expected computed total, got %q", total.Text)
+	}
+
+	if v, err := ctx.GetBool("canSave"); err != nil || v {
+		t.Errorf("Expected canSave to be false with empty fields, got %v, %v", v, err)
+	}
+
+	builder.GetWidget("first").(*widget.Entry).SetText("Ada")
+	builder.GetWidget("last").(*widget.Entry).SetText("Lovelace")
+
+	if full.Text != "Ada Lovelace" {
+		t.Errorf("Expected label to follow the computed value, got %q", full.Text)
+	}
+	if v, err := ctx.GetBool("canSave"); err != nil || !v {
+		t.Errorf("Expected canSave to be true, got %v, %v", v, err)
+	}
+
+	ctx.BindInt("qty", 3)
+	if total.Text != "€13.50" {
+		t.Errorf("Expected total to follow its dependencies, got %q", total.Text)
+	}
+
+	// Recomputing a key replaces the previous computation
+	ctx.Computed("total", []string{"qty"}, func(v []any) any {
+		qty, _ := v[0].(int)
+		return float64(qty)
+	})
+	ctx.BindFloat("unit", 10)
+	if total.Text != "€3.00" {
+		t.Errorf("Expected replaced computation, got %q", total.Text)
+	}
+}
+
+func TestComputedInvalidExpr(t *testing.T) {
+	builder := NewBuilder()
+	_, err := builder.BuildElement(Element{
+		XMLName:    xml.Name{Local: "Computed"},
+		Attributes: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: "x"}, {Name: xml.Name{Local: "expr"}, Value: "a +"}},
+	})
+	if err == nil {
+		t.Error("Expected an error for an invalid expression")
+	}
+}
+
+func TestInterpolation(t *testing.T) {
+	_ = test.NewApp()
+
+	type user struct {
+		Name string
+	}
+	u := &user{Name: "Ada"}
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<Label id="greeting">Hello ${user.Name}, you have ${count} items</Label>
+		<Text id="total">Total: ${count * price | currency:EUR}</Text>
+		<Label id="plural">${count == 1 ? 'one item' : count + ' items'}</Label>
+		<Label id="literal">Price: {{not a placeholder}}</Label>
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	ctx := builder.GetBindingContext()
+	userData := ctx.BindStruct("user", u)
+	ctx.BindInt("count", 2)
+	ctx.BindFloat("price", 1.5)
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	greeting := builder.GetWidget("greeting").(*widget.Label)
+	total := builder.GetElement("total").(*canvas.Text)
+	plural := builder.GetWidget("plural").(*widget.Label)
+
+	if greeting.Text != "Hello Ada, you have 2 items" {
+		t.Errorf("Unexpected greeting %q", greeting.Text)
+	}
+	if total.Text != "Total: €3.00" {
+		t.Errorf("Unexpected total %q", total.Text)
+	}
+	if plural.Text != "2 items" {
+		t.Errorf("Unexpected plural %q", plural.Text)
+	}
+	if got := builder.GetWidget("literal").(*widget.Label).Text; got != "Price: {{not a placeholder}}" {
+		t.Errorf("Expected text without placeholders to be unchanged, got %q", got)
+	}
+
+	ctx.BindInt("count", 1)
+	u.Name = "Grace"
+	if err := userData.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if greeting.Text != "Hello Grace, you have 1 items" {
+		t.Errorf("Expected greeting to follow the bindings, got %q", greeting.Text)
+	}
+	if total.Text != "Total: €1.50" {
+		t.Errorf("Expected total to follow the bindings, got %q", total.Text)
+	}
+	if plural.Text != "one item" {
+		t.Errorf("Expected plural to follow the bindings, got %q", plural.Text)
+	}
+}
+
+func TestInterpolationStoppedOnRebuild(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<Label id="count">${count} items</Label>
+		<Text id="total">Total: ${count}</Text>
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	ctx := builder.GetBindingContext()
+	ctx.BindInt("count", 1)
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+	old := builder.GetWidget("count").(*widget.Label)
+
+	for range 3 {
+		if _, err := builder.Rebuild(layout); err != nil {
+			t.Fatalf("Failed to rebuild: %v", err)
+		}
+	}
+	if n := len(ctx.watchers); n != 2 {
+		t.Errorf("Expected the watchers of the current tree only, got %d", n)
+	}
+
+	ctx.BindInt("count", 2)
+	if old.Text != "1 items" {
+		t.Errorf("Expected the replaced label to stop following count, got %q", old.Text)
+	}
+	if got := builder.GetWidget("count").(*widget.Label).Text; got != "2 items" {
+		t.Errorf("Expected the rebuilt label to follow count, got %q", got)
+	}
+
+	builder.Reset()
+	if n := len(ctx.watchers); n != 0 {
+		t.Errorf("Expected Reset to stop the watchers, got %d", n)
+	}
+}
+
+func TestParseInterpolation(t *testing.T) {
+	interp, err := parseInterpolation("${a || b} and ${'}' + c | upper} ${a}")
+	if err != nil {
+		t.Fatalf("parseInterpolation failed: %v", err)
+	}
+	if want := []string{"a", "b", "c"}; !slices.Equal(interp.keys, want) {
+		t.Errorf("keys = %v, want %v", interp.keys, want)
+	}
+	if len(interp.parts) != 5 || len(interp.parts[2].pipes) != 1 || interp.parts[2].pipes[0].name != "upper" {
+		t.Errorf("Unexpected parts %+v", interp.parts)
+	}
+
+	for _, text := range []string{"${open", "${a +}"} {
+		if _, err := parseInterpolation(text); err == nil {
+			t.Errorf("parseInterpolation(%q) should fail", text)
+		}
+	}
+}
+
+func TestPersist(t *testing.T) {
+	app := test.NewApp()
+	prefs := app.Preferences()
+	prefs.SetString("theme", "dark")
+	prefs.SetString("name", "alice")
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<Entry id="theme" bind="prefs:theme" />
+		<Checkbox id="sound" bind="prefs:sound" checked="true">Sound</Checkbox>
+		<Slider id="volume" bind="volume" min="0" max="10" value="5" />
+	</VBox>
+</Layout>
+`
+	build := func() *Builder {
+		builder := NewBuilder()
+		ctx := builder.GetBindingContext()
+		ctx.BindString("name", "guest")
+		if err := ctx.Persist("name"); err != nil {
+			t.Fatalf("Persist failed: %v", err)
+		}
+		if err := ctx.Persist("volume"); err != nil {
+			t.Fatalf("Persist failed: %v", err)
+		}
+
+		layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+		if err != nil {
+			t.Fatalf("Failed to load layout: %v", err)
+		}
+		if _, err := builder.Build(layout); err != nil {
+			t.Fatalf("Failed to build: %v", err)
+		}
+		return builder
+	}
+
+	builder := build()
+	ctx := builder.GetBindingContext()
+
+	// Stored values are restored, defaults are stored when missing
+	if text := builder.GetWidget("theme").(*widget.Entry).Text; text != "dark" {
+		t.Errorf("Expected the stored theme, got %q", text)
+	}
+	if name, _ := ctx.GetString("name"); name != "alice" {
+		t.Errorf("Expected the stored name, got %q", name)
+	}
+	if !builder.GetWidget("sound").(*widget.Check).Checked || !prefs.Bool("sound") {
+		t.Error("Expected the default to be stored")
+	}
+	if v := prefs.Float("volume"); v != 5 {
+		t.Errorf("Expected the persisted slider value, got %v", v)
+	}
+
+	// Changes are saved
+	builder.GetWidget("theme").(*widget.Entry).SetText("light")
+	builder.GetWidget("sound").(*widget.Check).SetChecked(false)
+	builder.GetWidget("volume").(*widget.Slider).SetValue(8)
+	ctx.BindString("name", "bob")
+	if prefs.String("theme") != "light" || prefs.Bool("sound") || prefs.Float("volume") != 8 || prefs.String("name") != "bob" {
+		t.Errorf("Expected changes to be saved, got %q %v %v %q",
+			prefs.String("theme"), prefs.Bool("sound"), prefs.Float("volume"), prefs.String("name"))
+	}
+
+	// A new layout starts from the saved values
+	builder = build()
+	if text := builder.GetWidget("theme").(*widget.Entry).Text; text != "light" {
+		t.Errorf("Expected the saved theme, got %q", text)
+	}
+	if builder.GetWidget("sound").(*widget.Check).Checked {
+		t.Error("Expected the saved checkbox state")
+	}
+	if v := builder.GetWidget("volume").(*widget.Slider).Value; v != 8 {
+		t.Errorf("Expected the saved volume, got %v", v)
+	}
+}
+
+func TestPersistBeforeBind(t *testing.T) {
+	app := test.NewApp()
+	prefs := app.Preferences()
+	prefs.SetInt("count", 7)
+	prefs.SetStringList("tags", []string{"c"})
+
+	layoutXML := `
+<Layout>
+	<CheckGroup id="tags" bind="prefs:tags">
+		<Option>a</Option>
+		<Option selected="true">b</Option>
+		<Option>c</Option>
+	</CheckGroup>
+</Layout>
+`
+	builder := NewBuilder()
+	ctx := builder.GetBindingContext()
+	for _, key := range []string{"count", "ratio", "count"} {
+		if err := ctx.Persist(key); err != nil {
+			t.Fatalf("Persist failed: %v", err)
+		}
+	}
+
+	// Stored values win over the ones passed to Bind, defaults are stored
+	count := ctx.BindInt("count", 1)
+	if v, _ := count.Get(); v != 7 {
+		t.Errorf("Expected the stored count, got %d", v)
+	}
+	ctx.BindFloat("ratio", 0.5)
+	if v := prefs.Float("ratio"); v != 0.5 {
+		t.Errorf("Expected the default ratio to be stored, got %v", v)
+	}
+	ctx.BindInt("count", 3)
+	if v := prefs.Int("count"); v != 3 {
+		t.Errorf("Expected changes to be saved, got %d", v)
+	}
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	group := builder.GetWidget("tags").(*widget.CheckGroup)
+	if !slices.Equal(group.Selected, []string{"c"}) {
+		t.Errorf("Expected the stored selection, got %v", group.Selected)
+	}
+	group.SetSelected([]string{"a", "b"})
+	if tags := prefs.StringList("tags"); !slices.Equal(tags, []string{"a", "b"}) {
+		t.Errorf("Expected the selection to be saved, got %v", tags)
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	test.NewApp()
+
+	type user struct {
+		Name string
+		Age  int
+	}
+	u := &user{Name: "Ada", Age: 36}
+
+	ctx := NewBindingContext()
+	ctx.BindString("title", "Draft")
+	ctx.BindInt("count", 3)
+	ctx.BindBool("done", false)
+	ctx.BindStringList("tags", []string{"a", "b"})
+	ctx.BindStruct("user", u)
+	ctx.Computed("summary", []string{"title"}, func(v []any) any { return v[0] })
+
+	snapshot := ctx.Snapshot()
+	if _, ok := snapshot["summary"]; ok {
+		t.Error("Computed keys should not be in the snapshot")
+	}
+	if fields, _ := snapshot["user"].(map[string]any); fields["Name"] != "Ada" {
+		t.Errorf("Expected the struct fields, got %v", snapshot["user"])
+	}
+
+	data, err := ctx.ExportJSON()
+	if err != nil {
+		t.Fatalf("ExportJSON failed: %v", err)
+	}
+
+	ctx.BindString("title", "Final")
+	ctx.BindInt("count", 7)
+	ctx.BindStringList("tags", nil)
+	_ = ctx.Restore(Snapshot{"user": map[string]any{"Name": "Bob"}}) //nolint:errcheck // Checked below
+	if u.Name != "Bob" {
+		t.Errorf("Expected the struct field to be restored, got %q", u.Name)
+	}
+
+	// Numbers and lists decoded from JSON are converted to the binding types
+	if err := ctx.ImportJSON(data); err != nil {
+		t.Fatalf("ImportJSON failed: %v", err)
+	}
+	if title, _ := ctx.GetString("title"); title != "Draft" {
+		t.Errorf("Expected the exported title, got %q", title)
+	}
+	if count, _ := ctx.GetInt("count"); count != 3 {
+		t.Errorf("Expected the exported count, got %d", count)
+	}
+	if tags, _ := ctx.GetStringList("tags"); !slices.Equal(tags, []string{"a", "b"}) {
+		t.Errorf("Expected the exported tags, got %v", tags)
+	}
+	if u.Name != "Ada" || u.Age != 36 {
+		t.Errorf("Expected the exported struct, got %+v", *u)
+	}
+	if summary, _ := ctx.GetString("summary"); summary != "Draft" {
+		t.Errorf("Expected the computed key to follow, got %q", summary)
+	}
+
+	// New keys are bound, invalid values are reported
+	if err := ctx.Restore(Snapshot{"extra": "x", "count": "many"}); err == nil {
+		t.Error("Expected an error for an invalid value")
+	}
+	if extra, _ := ctx.GetString("extra"); extra != "x" {
+		t.Errorf("Expected a new key to be bound, got %q", extra)
+	}
+	if err := ctx.ImportJSON([]byte("{")); err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}
+
+func TestHistory(t *testing.T) {
+	test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<Entry id="title" bind="title" />
+		<Slider id="size" bind="size" min="0" max="10" />
+		<Computed key="upper" expr="title + '!'" />
+		<Button id="undo" onclick="undo">Undo</Button>
+		<Button id="redo" onclick="redo">Redo</Button>
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	builder.SetStrictEvents(true)
+	ctx := builder.GetBindingContext()
+	ctx.BindString("title", "")
+	ctx.EnableHistory(time.Hour)
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Built-in actions should be handled: %v", err)
+	}
+	if ctx.CanUndo() {
+		t.Fatal("Building should not record changes")
+	}
+
+	entry := builder.GetWidget("title").(*widget.Entry)
+	slider := builder.GetWidget("size").(*widget.Slider)
+
+	// Typing is coalesced into one step
+	test.Type(entry, "abc")
+	slider.SetValue(4)
+	if !ctx.Undo() || slider.Value != 0 || entry.Text != "abc" {
+		t.Errorf("Expected the slider change to be undone, got %v %q", slider.Value, entry.Text)
+	}
+	test.Tap(builder.GetWidget("undo").(*widget.Button))
+	if entry.Text != "" || !ctx.CanRedo() {
+		t.Errorf("Expected the typing to be undone at once, got %q", entry.Text)
+	}
+	if ctx.Undo() {
+		t.Error("Expected nothing left to undo")
+	}
+
+	test.Tap(builder.GetWidget("redo").(*widget.Button))
+	if entry.Text != "abc" {
+		t.Errorf("Expected the typing to be redone, got %q", entry.Text)
+	}
+
+	// A new change clears the redo steps and is not merged into a redone one
+	entry.SetText("abcd")
+	if ctx.CanRedo() {
+		t.Error("Expected a new change to clear the redo steps")
+	}
+	ctx.Undo()
+	if entry.Text != "abc" {
+		t.Errorf("Expected only the last change to be undone, got %q", entry.Text)
+	}
+
+	// A restore is a single step
+	ctx.ClearHistory()
+	if err := ctx.Restore(Snapshot{"title": "x", "size": 9}); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	ctx.Undo()
+	if entry.Text != "abc" || slider.Value != 0 {
+		t.Errorf("Expected the restore to be undone, got %q %v", entry.Text, slider.Value)
+	}
+	if ctx.CanUndo() {
+		t.Error("Expected the computed key not to be recorded")
+	}
+}
+
+func TestBindMapConcurrent(t *testing.T) {
+	test.NewApp()
+
+	settings := map[string]any{"theme": "light"}
+	ctx := NewBindingContext()
+	ctx.BindMap("settings", &settings)
+	data, ok := ctx.GetBinding("settings.theme")
+	if !ok {
+		t.Fatal("Expected the map entry to be bound")
+	}
+	entry := data.(binding.Untyped)
+
+	// Listeners read the entry while it is being set
+	entry.AddListener(binding.NewDataListener(func() {
+		_, _ = entry.Get() //nolint:errcheck // Only the read matters
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			_ = entry.Set(fmt.Sprintf("theme%d", i)) //nolint:errcheck // Untyped values are always set
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		if _, err := entry.Get(); err != nil {
+			t.Errorf("Get failed: %v", err)
+		}
+	}
+	<-done
+
+	if v, _ := ctx.GetValue("settings.theme"); v != "theme199" {
+		t.Errorf("Expected the last value, got %v", v)
+	}
+}
+
+func TestComputedBeforeBind(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<Computed key="total" expr="qty * price" />
+		<Label id="summary" text="${qty} x ${price}" />
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	// The data is bound after the layout declared the computation
+	ctx := builder.GetBindingContext()
+	ctx.BindInt("qty", 3)
+	ctx.BindFloat("price", 2.5)
+	if v, err := ctx.GetValue("total"); err != nil || formatValue(v) != "7.5" {
+		t.Errorf("Expected the computed value to follow the new bindings, got %v, %v", v, err)
+	}
+	if text := builder.GetWidget("summary").(*widget.Label).Text; text != "3 x 2.5" {
+		t.Errorf("Expected the interpolation to follow the new bindings, got %q", text)
+	}
+
+	ctx.BindInt("qty", 4)
+	if v, _ := ctx.GetValue("total"); formatValue(v) != "10" {
+		t.Errorf("Expected the computed value to update, got %v", v)
+	}
+}
@@ -0,0 +1,148 @@
+package fylay
+
+import (
+	"maps"
+	"sync"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// buildScope raccoglie quanto legato a un'interfaccia costruita, da
+// rilasciare quando Build, Rebuild o Reset la sostituiscono
+type buildScope struct {
+	mu       sync.Mutex
+	released bool
+	cleanups []func()
+	fields   map[string]*fieldValidation // Campi validati, per ID
+	forms    map[string]*formState       // Form costruiti, per ID
+	tables   map[string]*tableView       // Righe delle Table con ID
+}
+
+// onRelease registra una funzione da chiamare al rilascio; se lo scope è
+// già stato rilasciato viene chiamata subito
+func (s *buildScope) onRelease(fn func()) {
+	s.mu.Lock()
+	if !s.released {
+		s.cleanups = append(s.cleanups, fn)
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+	fn()
+}
+
+// listen aggiunge un listener a un binding, rimosso al rilascio
+func (s *buildScope) listen(data binding.DataItem, listener binding.DataListener) {
+	data.AddListener(listener)
+	s.onRelease(func() {
+		data.RemoveListener(listener)
+	})
+}
+
+// release chiama le funzioni registrate; le chiamate successive non hanno
+// effetto
+func (s *buildScope) release() {
+	s.mu.Lock()
+	if s.released {
+		s.mu.Unlock()
+		return
+	}
+	s.released = true
+	cleanups := s.cleanups
+	s.cleanups = nil
+	s.mu.Unlock()
+
+	for _, fn := range cleanups {
+		fn()
+	}
+}
+
+// addField registra un campo validato con ID
+func (s *buildScope) addField(id string, field *fieldValidation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fields == nil {
+		s.fields = make(map[string]*fieldValidation)
+	}
+	s.fields[id] = field
+}
+
+// field restituisce il campo validato con l'ID dato
+func (s *buildScope) field(id string) (*fieldValidation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	field, ok := s.fields[id]
+	return field, ok
+}
+
+// allFields restituisce una copia dei campi validati, per ID
+func (s *buildScope) allFields() map[string]*fieldValidation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.fields)
+}
+
+// addForm registra un form con ID
+func (s *buildScope) addForm(id string, state *formState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.forms == nil {
+		s.forms = make(map[string]*formState)
+	}
+	s.forms[id] = state
+}
+
+// form restituisce il form con l'ID dato
+func (s *buildScope) form(id string) (*formState, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.forms[id]
+	return state, ok
+}
+
+// addTable registra le righe di una Table con ID
+func (s *buildScope) addTable(id string, view *tableView) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tables == nil {
+		s.tables = make(map[string]*tableView)
+	}
+	s.tables[id] = view
+}
+
+// table restituisce le righe della Table con l'ID dato
+func (s *buildScope) table(id string) (*tableView, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	view, ok := s.tables[id]
+	return view, ok
+}
+
+// currentScope restituisce lo scope dell'interfaccia corrente
+func (b *Builder) currentScope() *buildScope {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.scope
+}
+
+// buildingScope restituisce lo scope dell'interfaccia in costruzione: quello
+// di Rebuild mentre è in corso, altrimenti quello dell'interfaccia corrente
+func (b *Builder) buildingScope() *buildScope {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.nextScope != nil {
+		return b.nextScope
+	}
+	return b.scope
+}
+
+// replaceScope rende corrente un nuovo scope e rilascia il precedente
+func (b *Builder) replaceScope(next *buildScope) {
+	b.mu.Lock()
+	prev := b.scope
+	b.scope = next
+	b.nextScope = nil
+	b.mu.Unlock()
+
+	prev.release()
+}
@@ -0,0 +1,162 @@
+package fylay
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+// These tests are meant to be run with the race detector (go test -race)
+
+const concurrencyLayoutXML = `
+<Layout>
+	<Style selector=".title">font-weight: bold;</Style>
+	<VBox>
+		<Label id="title" class="title">Title</Label>
+		<Entry id="name" onchange="onName" />
+		<Checkbox id="agree" bind="agree" />
+		<Slider id="volume" bind="volume" />
+		<Button id="save" onclick="onSave">Save</Button>
+	</VBox>
+</Layout>
+`
+
+func TestConcurrentBuilderAccess(t *testing.T) {
+	_ = test.NewApp()
+
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(concurrencyLayoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	const workers = 8
+
+	for i := 0; i < workers; i++ {
+		wg.Add(4)
+
+		go func() {
+			defer wg.Done()
+			if _, err := builder.Build(layout); err != nil {
+				t.Errorf("Build failed: %v", err)
+			}
+		}()
+
+		go func(i int) {
+			defer wg.Done()
+			builder.On(fmt.Sprintf("event%d", i), func(ctx *EventContext) {})
+			builder.OnEntry(fmt.Sprintf("entry%d", i), func(ctx *EventContext) {})
+			builder.SetEventHandler(&testEventHandler{})
+		}(i)
+
+		go func() {
+			defer wg.Done()
+			_ = builder.GetWidget("title")
+			_ = builder.GetElement("save")
+			_ = builder.ComputeStyle(layout.Root)
+			_ = builder.GetStyles()
+		}()
+
+		go func(i int) {
+			defer wg.Done()
+			ctx := builder.GetBindingContext()
+			ctx.BindString(fmt.Sprintf("key%d", i%2), "value")
+			_, _ = ctx.GetString("key0")
+			_, _ = ctx.GetFloat("volume")
+			_, _ = ctx.GetBool("agree")
+			builder.SetTemplateVariable(fmt.Sprintf("var%d", i), i)
+		}(i)
+	}
+
+	wg.Wait()
+
+	if _, ok := builder.GetWidget("name").(*widget.Entry); !ok {
+		t.Error("Expected name to be an Entry after concurrent builds")
+	}
+}
+
+func TestConcurrentBindingContext(t *testing.T) {
+	ctx := NewBindingContext()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("k%d", i%4)
+			ctx.BindString(key+"s", "v")
+			ctx.BindInt(key+"i", i)
+			ctx.BindFloat(key+"f", float64(i))
+			ctx.BindBool(key+"b", i%2 == 0)
+			ctx.RegisterWidget(key, widget.NewLabel(key))
+			_, _ = ctx.GetWidget(key)
+			_, _ = ctx.GetInt(key + "i")
+			_ = ctx.BindWidgetToData(key, key+"s")
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < 4; i++ {
+		key := fmt.Sprintf("k%d", i)
+		if _, err := ctx.GetString(key + "s"); err != nil {
+			t.Errorf("Expected string binding %s: %v", key, err)
+		}
+	}
+}
+
+func TestConcurrentHotReload(t *testing.T) {
+	_ = test.NewApp()
+
+	path := filepath.Join(t.TempDir(), "layout.xml")
+	if err := os.WriteFile(path, []byte(concurrencyLayoutXML), 0o600); err != nil {
+		t.Fatalf("Failed to write layout: %v", err)
+	}
+
+	builder, _, err := LoadLayoutFromFile(path)
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+
+	config := NewHotReloadConfig(path)
+	config.OnReload = func(content fyne.CanvasObject) {}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	// Readers keep querying widgets while the layout is reloaded
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					if builder.GetWidget("title") == nil {
+						t.Error("title disappeared during reload")
+						return
+					}
+					builder.On("onSave", func(ctx *EventContext) {})
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		if err := builder.reloadLayout(config); err != nil {
+			t.Errorf("Reload failed: %v", err)
+		}
+	}
+
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,136 @@
+package fylay
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+)
+
+// controllerTag è il tag dei campi del controller collegati ai widget
+const controllerTag = "fylay"
+
+// BindController collega un controller al builder tramite reflection:
+//   - i metodi esportati con firma func(*EventContext) diventano handler,
+//     registrati sia con il nome del metodo sia con l'iniziale minuscola
+//     (Save risponde a "Save" e "save", OnSave a "onSave");
+//   - i campi con tag `fylay:"id"` ricevono il widget con quell'ID
+//     (serve un puntatore a struct).
+//
+// Va chiamato dopo Build, così i widget esistono e gli handler usati nel
+// layout possono essere verificati. Le diagnostiche restituite segnalano gli
+// handler senza callback, i metodi con firma errata e i campi non collegati.
+func (b *Builder) BindController(ctrl any) ([]Diagnostic, error) {
+	v := reflect.ValueOf(ctrl)
+	if !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return nil, errors.New("controller is nil")
+	}
+
+	// Metodi con firma errata, per segnalarli se usati nel layout
+	wrongSignature := make(map[string]reflect.Method)
+
+	t := v.Type()
+	for i := 0; i < t.NumMethod(); i++ {
+		method := t.Method(i)
+		names := handlerNames(method.Name)
+
+		fn, ok := v.Method(i).Interface().(func(*EventContext))
+		if !ok {
+			for _, name := range names {
+				wrongSignature[name] = method
+			}
+			continue
+		}
+
+		for _, name := range names {
+			b.On(name, fn)
+		}
+		b.setHandlerAliases(names)
+	}
+
+	diagnostics := b.bindControllerFields(v)
+
+	for _, ref := range b.eventReferences() {
+		if ref.err != nil {
+			diagnostics = append(diagnostics, ref.diagnostic("invalid handler: %v", ref.err))
+			continue
+		}
+		if _, ok := b.callback(ref.expr.name); ok {
+			continue
+		}
+		if method, ok := wrongSignature[ref.expr.name]; ok {
+			diagnostics = append(diagnostics, ref.diagnostic(
+				"method %s has signature %s, expected func(*fylay.EventContext)", method.Name, method.Type))
+			continue
+		}
+		diagnostics = append(diagnostics, ref.diagnostic("no method for handler %q", ref.expr.name))
+	}
+
+	return diagnostics, nil
+}
+
+// bindControllerFields assegna i widget ai campi con tag fylay
+func (b *Builder) bindControllerFields(v reflect.Value) []Diagnostic {
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	v = v.Elem()
+
+	var diagnostics []Diagnostic
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		id, ok := field.Tag.Lookup(controllerTag)
+		if !ok || id == "" || id == "-" {
+			continue
+		}
+
+		fieldDiagnostic := func(format string, args ...any) {
+			diagnostics = append(diagnostics, Diagnostic{
+				ElementID: id,
+				Handler:   field.Name,
+				Message:   fmt.Sprintf(format, args...),
+			})
+		}
+
+		if !field.IsExported() {
+			fieldDiagnostic("field %s is not exported", field.Name)
+			continue
+		}
+
+		w := b.GetWidget(id)
+		if w == nil {
+			fieldDiagnostic("no element with id %q for field %s", id, field.Name)
+			continue
+		}
+
+		if !reflect.TypeOf(w).AssignableTo(field.Type) {
+			fieldDiagnostic("element %q is %T, not assignable to field %s of type %s", id, w, field.Name, field.Type)
+			continue
+		}
+
+		v.Field(i).Set(reflect.ValueOf(w))
+	}
+
+	return diagnostics
+}
+
+// setHandlerAliases registra i nomi alternativi di un metodo del controller,
+// così UnusedHandlers non segnala quello che il layout non usa
+func (b *Builder) setHandlerAliases(names []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, alias := range names[1:] {
+		b.handlerAliases[alias] = names[0]
+	}
+}
+
+// handlerNames restituisce i nomi di handler associati a un metodo
+func handlerNames(method string) []string {
+	r, size := utf8.DecodeRuneInString(method)
+	lower := string(unicode.ToLower(r)) + method[size:]
+	if lower == method {
+		return []string{method}
+	}
+	return []string{method, lower}
+}
@@ -0,0 +1,122 @@
+package fylay
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+type testController struct {
+	Username *widget.Entry  `fylay:"usernameField"`
+	Save     *widget.Button `fylay:"saveBtn"`
+	Wrong    *widget.Label  `fylay:"saveBtn"`
+	Missing  *widget.Label  `fylay:"nope"`
+	hidden   *widget.Entry  `fylay:"usernameField"` //nolint:unused // Checked by BindController
+
+	saved    string
+	canceled bool
+}
+
+func (c *testController) OnSave(ctx *EventContext) {
+	c.saved = c.Username.Text
+}
+
+func (c *testController) Cancel(ctx *EventContext) {
+	c.canceled = true
+}
+
+func (c *testController) Reset() {}
+
+func TestBindController(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<Entry id="usernameField" />
+		<Button id="saveBtn" onclick="onSave">Save</Button>
+		<Button id="cancelBtn" onclick="cancel">Cancel</Button>
+		<Button id="resetBtn" onclick="reset">Reset</Button>
+		<Button id="helpBtn" onclick="help">Help</Button>
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	ctrl := &testController{}
+	diagnostics, err := builder.BindController(ctrl)
+	if err != nil {
+		t.Fatalf("BindController failed: %v", err)
+	}
+
+	if ctrl.Username != builder.GetWidget("usernameField") || ctrl.Save != builder.GetWidget("saveBtn") {
+		t.Error("Expected tagged fields to receive the widgets")
+	}
+
+	ctrl.Username.SetText("ada")
+	test.Tap(ctrl.Save)
+	test.Tap(builder.GetWidget("cancelBtn").(*widget.Button))
+	if ctrl.saved != "ada" || !ctrl.canceled {
+		t.Errorf("Expected controller methods to handle events, got saved=%q canceled=%v", ctrl.saved, ctrl.canceled)
+	}
+
+	var got []string
+	for _, d := range diagnostics {
+		got = append(got, d.Handler)
+	}
+	want := []string{"Wrong", "Missing", "hidden", "reset", "help"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Unexpected diagnostics %v", diagnostics)
+	}
+	if msg := diagnostics[3].String(); !strings.Contains(msg, "Button#resetBtn onclick") || !strings.Contains(msg, "signature") {
+		t.Errorf("Unexpected diagnostic message %q", msg)
+	}
+
+	if _, err := builder.BindController(nil); err == nil {
+		t.Error("Expected error for nil controller")
+	}
+}
+
+func TestBindControllerUnusedHandlers(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<Entry id="usernameField" />
+		<Button id="saveBtn" onclick="onSave">Save</Button>
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+	if _, err := builder.BindController(&testController{}); err != nil {
+		t.Fatalf("BindController failed: %v", err)
+	}
+
+	// OnSave is used through its alias, Cancel is reported once
+	if unused := builder.UnusedHandlers(); strings.Join(unused, ",") != "Cancel" {
+		t.Errorf("Unexpected unused handlers %v", unused)
+	}
+
+	// A callback registered with an alias name is a handler of its own
+	builder.On("cancel", func(*EventContext) {})
+	if unused := builder.UnusedHandlers(); strings.Join(unused, ",") != "Cancel,cancel" {
+		t.Errorf("Unexpected unused handlers %v", unused)
+	}
+}
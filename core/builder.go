@@ -1,134 +1,222 @@
 // Package core contains the core types and builder for Fylay.
-// This package provides the fundamental structures for parsing XML layouts
-// and building Fyne UI components.
+// This package provides the fundamental structures for parsing layouts,
+// computing styles and building Fyne UI components through a registry
+// of element factories. Widget packages only need to depend on core.
 package core
 
 import (
-	"encoding/xml"
 	"fmt"
 	"io"
+	"sync"
 
 	"fyne.io/fyne/v2"
 )
 
-// Layout represents a parsed XML layout
-type Layout struct {
-	XMLName xml.Name `xml:"Layout"`
-	Styles  []Style  `xml:"Style"`
-	Root    Element  `xml:",any"`
-}
-
-// Style represents a CSS style rule
-type Style struct {
-	Selector   string            `xml:"selector,attr"`
-	Properties map[string]string `xml:"-"`
-	RawCSS     string            `xml:",innerxml"`
-}
-
-// Element represents a generic layout element
-type Element struct {
-	XMLName    xml.Name
-	ID         string     `xml:"id,attr"`
-	Class      string     `xml:"class,attr"`
-	Style      string     `xml:"style,attr"`
-	Text       string     `xml:"text,attr"`
-	Attributes []xml.Attr `xml:",any,attr"`
-	Children   []Element  `xml:",any"`
-	Content    string     `xml:",chardata"`
-}
-
-// GetAttr returns the value of an attribute by name
-func (e *Element) GetAttr(name string) string {
-	for _, attr := range e.Attributes {
-		if attr.Name.Local == name {
-			return attr.Value
-		}
+// Builder builds Fyne widgets from layouts.
+// It is safe for concurrent use: lookups may run while a build or a
+// rebuild is in progress, and builds are serialized.
+type Builder struct {
+	mu        sync.RWMutex
+	buildMu   sync.Mutex  // Serializes Build and Rebuild
+	state     *buildState // Committed state, visible to lookups
+	pending   *buildState // State being filled by Rebuild, nil otherwise
+	factories map[string]ElementFactory
+}
+
+// buildState holds the styles and objects produced by a build
+type buildState struct {
+	layout   *Layout // Last layout built
+	styles   map[string]Style
+	elements map[string]fyne.CanvasObject
+	widgets  map[string]fyne.CanvasObject // Original widgets before wrapping
+}
+
+// newBuildState creates an empty build state
+func newBuildState() *buildState {
+	return &buildState{
+		styles:   make(map[string]Style),
+		elements: make(map[string]fyne.CanvasObject),
+		widgets:  make(map[string]fyne.CanvasObject),
 	}
-	return ""
 }
 
-// EventHandler handles widget events
-type EventHandler interface {
-	OnButtonTapped(id string)
-	OnEntryChanged(id, value string)
+// NewBuilder creates a new builder instance
+func NewBuilder() *Builder {
+	return &Builder{
+		state:     newBuildState(),
+		factories: make(map[string]ElementFactory),
+	}
 }
 
-// Builder builds Fyne widgets from layouts
-type Builder struct {
-	styles         map[string]Style
-	elements       map[string]fyne.CanvasObject
-	eventHandler   EventHandler
-	eventCallbacks map[string]func()
-	entryCallbacks map[string]func(string)
+// target returns the state written by builds. The caller must hold b.mu.
+func (b *Builder) target() *buildState {
+	if b.pending != nil {
+		return b.pending
+	}
+	return b.state
 }
 
-// NewBuilder creates a new builder instance
-func NewBuilder() *Builder {
-	return &Builder{
-		styles:         make(map[string]Style),
-		elements:       make(map[string]fyne.CanvasObject),
-		eventCallbacks: make(map[string]func()),
-		entryCallbacks: make(map[string]func(string)),
+// LoadLayout loads a layout from an XML reader
+func (b *Builder) LoadLayout(r io.Reader) (*Layout, error) {
+	return b.LoadLayoutFormat(r, FormatXML)
+}
+
+// LoadLayoutYAML loads a layout from a YAML reader
+func (b *Builder) LoadLayoutYAML(r io.Reader) (*Layout, error) {
+	return b.LoadLayoutFormat(r, FormatYAML)
+}
+
+// LoadLayoutJSON loads a layout from a JSON reader
+func (b *Builder) LoadLayoutJSON(r io.Reader) (*Layout, error) {
+	return b.LoadLayoutFormat(r, FormatJSON)
+}
+
+// LoadLayoutFormat loads a layout from a reader using the given format
+// and registers its styles with the builder
+func (b *Builder) LoadLayoutFormat(r io.Reader, format LayoutFormat) (*Layout, error) {
+	layout, err := ParseLayout(r, format)
+	if err != nil {
+		return nil, err
 	}
+
+	b.RegisterStyles(layout)
+
+	return layout, nil
 }
 
-// SetEventHandler sets the event handler for the builder
-func (b *Builder) SetEventHandler(handler EventHandler) {
-	b.eventHandler = handler
+// RegisterStyles registers the styles of a layout with the builder
+func (b *Builder) RegisterStyles(layout *Layout) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st := b.target()
+	for _, style := range layout.Styles {
+		st.styles[style.Selector] = style
+	}
 }
 
-// On registers a callback for a specific event (for buttons)
-func (b *Builder) On(eventName string, callback func()) {
-	b.eventCallbacks[eventName] = callback
+// Reset clears styles and built elements, keeping registered factories
+func (b *Builder) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = newBuildState()
 }
 
-// OnEntry registers a callback for Entry events
-func (b *Builder) OnEntry(eventName string, callback func(string)) {
-	b.entryCallbacks[eventName] = callback
+// Build builds the user interface from a layout
+func (b *Builder) Build(layout *Layout) (fyne.CanvasObject, error) {
+	b.buildMu.Lock()
+	defer b.buildMu.Unlock()
+
+	b.mu.Lock()
+	b.state.layout = layout
+	b.mu.Unlock()
+
+	return b.BuildElement(layout.Root)
 }
 
-// LoadLayout loads a layout from an XML reader
-func (b *Builder) LoadLayout(r io.Reader) (*Layout, error) {
-	var layout Layout
-	decoder := xml.NewDecoder(r)
-	if err := decoder.Decode(&layout); err != nil {
-		return nil, fmt.Errorf("XML parsing error: %w", err)
+// Rebuild builds a layout from scratch, replacing the current styles and
+// elements only once the build succeeds. Lookups made while the rebuild is
+// running keep seeing the previous state.
+func (b *Builder) Rebuild(layout *Layout) (fyne.CanvasObject, error) {
+	b.buildMu.Lock()
+	defer b.buildMu.Unlock()
+
+	next := newBuildState()
+	next.layout = layout
+	for _, style := range layout.Styles {
+		next.styles[style.Selector] = style
+	}
+
+	b.mu.Lock()
+	b.pending = next
+	b.mu.Unlock()
+
+	obj, err := b.BuildElement(layout.Root)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending = nil
+	if err != nil {
+		return nil, err
 	}
+	b.state = next
 
-	// Parse CSS styles - Note: this requires parseCSS function from styles package
-	// This will be refactored when we move the actual implementation
-	for i := range layout.Styles {
-		// Placeholder: actual parsing will be done by styles package
-		layout.Styles[i].Properties = make(map[string]string)
-		b.styles[layout.Styles[i].Selector] = layout.Styles[i]
+	return obj, nil
+}
+
+// BuildElement builds a single element using the factory registered for its type
+func (b *Builder) BuildElement(elem Element) (fyne.CanvasObject, error) {
+	factory, ok := b.Factory(elem.XMLName.Local)
+	if !ok {
+		return nil, fmt.Errorf("unknown element type: %s", elem.XMLName.Local)
 	}
 
-	return &layout, nil
+	return factory(b, elem, b.ComputeStyle(elem))
+}
+
+// BuildChildren builds the children of an element, skipping the ones that fail
+func (b *Builder) BuildChildren(elem Element) []fyne.CanvasObject {
+	children := make([]fyne.CanvasObject, 0, len(elem.Children))
+	for _, child := range elem.Children {
+		if obj, err := b.BuildElement(child); err == nil && obj != nil {
+			children = append(children, obj)
+		}
+	}
+	return children
 }
 
 // GetElement returns an element by ID
 func (b *Builder) GetElement(id string) fyne.CanvasObject {
-	return b.elements[id]
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.state.elements[id]
 }
 
-// GetStyles returns the loaded styles
-func (b *Builder) GetStyles() map[string]Style {
-	return b.styles
+// GetWidget returns the original (unwrapped) widget by ID
+func (b *Builder) GetWidget(id string) fyne.CanvasObject {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if w, ok := b.state.widgets[id]; ok {
+		return w
+	}
+	return b.state.elements[id] // Fallback to element if no widget
 }
 
-// GetEventCallback returns a registered event callback
-func (b *Builder) GetEventCallback(name string) func() {
-	return b.eventCallbacks[name]
+// Layout returns the last layout built, or nil if nothing was built yet
+func (b *Builder) Layout() *Layout {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.state.layout
 }
 
-// GetEntryCallback returns a registered entry callback
-func (b *Builder) GetEntryCallback(name string) func(string) {
-	return b.entryCallbacks[name]
+// GetStyles returns a copy of the loaded styles
+func (b *Builder) GetStyles() map[string]Style {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	styles := make(map[string]Style, len(b.state.styles))
+	for k, v := range b.state.styles {
+		styles[k] = v
+	}
+	return styles
 }
 
-// RegisterElement registers an element with the builder
+// RegisterElement registers the final (possibly wrapped) object for an ID
 func (b *Builder) RegisterElement(id string, obj fyne.CanvasObject) {
-	if id != "" {
-		b.elements[id] = obj
+	if id == "" {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.target().elements[id] = obj
+}
+
+// RegisterWidget registers the original widget for an ID, before any wrapping
+func (b *Builder) RegisterWidget(id string, w fyne.CanvasObject) {
+	if id == "" {
+		return
 	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.target().widgets[id] = w
 }
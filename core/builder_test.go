@@ -0,0 +1,115 @@
+package core
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+// testBox builds its children in a VBox container
+func testBox(b *Builder, elem Element, style map[string]string) (fyne.CanvasObject, error) {
+	return container.NewVBox(b.BuildChildren(elem)...), nil
+}
+
+// testText builds a label and registers it by ID
+func testText(b *Builder, elem Element, style map[string]string) (fyne.CanvasObject, error) {
+	label := widget.NewLabel(strings.TrimSpace(elem.Content))
+	b.RegisterWidget(elem.ID, label)
+	styled := ApplyMinSize(label, style)
+	b.RegisterElement(elem.ID, styled)
+	return styled, nil
+}
+
+func TestBuilderFactories(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<Style selector="#title">width: 120</Style>
+	<TestBox>
+		<TestText id="title">Hello</TestText>
+		<Unknown id="skipped" />
+	</TestBox>
+</Layout>
+`
+
+	builder := NewBuilder()
+	builder.RegisterFactory("TestBox", testBox)
+	builder.RegisterFactory("TestText", testText)
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+
+	content, err := builder.Build(layout)
+	if err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	box, ok := content.(*fyne.Container)
+	if !ok {
+		t.Fatalf("Expected container, got %T", content)
+	}
+	if len(box.Objects) != 1 {
+		t.Errorf("Expected unknown child to be skipped, got %d objects", len(box.Objects))
+	}
+
+	label, ok := builder.GetWidget("title").(*widget.Label)
+	if !ok || label.Text != "Hello" {
+		t.Fatalf("Expected title text widget, got %v", builder.GetWidget("title"))
+	}
+	if builder.GetElement("title").MinSize().Width < 120 {
+		t.Errorf("Expected ID style width to be applied, got %v", builder.GetElement("title").MinSize())
+	}
+
+	if builder.Layout() != layout {
+		t.Error("Expected Layout to return the built layout")
+	}
+
+	builder.Reset()
+	if builder.GetElement("title") != nil || len(builder.GetStyles()) != 0 || builder.Layout() != nil {
+		t.Error("Expected Reset to clear layout, elements and styles")
+	}
+	if _, ok := builder.Factory("TestBox"); !ok {
+		t.Error("Expected Reset to keep factories")
+	}
+}
+
+func TestGlobalRegistry(t *testing.T) {
+	Register("GlobalText", testText)
+
+	builder := NewBuilder()
+	if _, ok := builder.Factory("GlobalText"); !ok {
+		t.Fatal("Expected global factory to be visible to new builders")
+	}
+
+	found := false
+	for _, name := range RegisteredTypes() {
+		if name == "GlobalText" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected GlobalText in RegisteredTypes")
+	}
+
+	// Builder factories take precedence over the global registry
+	builder.RegisterFactory("GlobalText", func(b *Builder, elem Element, style map[string]string) (fyne.CanvasObject, error) {
+		return nil, fmt.Errorf("local factory")
+	})
+	_, err := builder.BuildElement(Element{XMLName: xml.Name{Local: "GlobalText"}})
+	if err == nil || err.Error() != "local factory" {
+		t.Errorf("Expected local factory to win, got %v", err)
+	}
+
+	if _, err := builder.BuildElement(Element{XMLName: xml.Name{Local: "Missing"}}); err == nil {
+		t.Error("Expected error for unknown element type")
+	}
+}
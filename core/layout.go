@@ -0,0 +1,287 @@
+package core
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Layout represents a parsed layout document
+type Layout struct {
+	XMLName xml.Name `xml:"Layout"`
+	Styles  []Style  `xml:"Style"`
+	Root    Element  `xml:",any"`
+}
+
+// Style represents a CSS style rule
+type Style struct {
+	Selector   string            `xml:"selector,attr"`
+	Properties map[string]string `xml:"-"`
+	RawCSS     string            `xml:",innerxml"`
+}
+
+// Element represents a generic layout element
+type Element struct {
+	XMLName    xml.Name
+	ID         string     `xml:"id,attr"`
+	Class      string     `xml:"class,attr"`
+	Style      string     `xml:"style,attr"`
+	Text       string     `xml:"text,attr"`
+	Attributes []xml.Attr `xml:",any,attr"`
+	Children   []Element  `xml:",any"`
+	Content    string     `xml:",chardata"`
+}
+
+// GetAttr returns the value of an attribute by name
+func (e *Element) GetAttr(name string) string {
+	for _, attr := range e.Attributes {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// LayoutFormat identifies the syntax of a layout document
+type LayoutFormat string
+
+// Supported layout formats
+const (
+	FormatXML  LayoutFormat = "xml"
+	FormatYAML LayoutFormat = "yaml"
+	FormatJSON LayoutFormat = "json"
+)
+
+// Reserved keys of an element node in YAML/JSON layouts.
+// Every other scalar key is treated as an attribute.
+const (
+	nodeKeyType     = "type"
+	nodeKeyID       = "id"
+	nodeKeyClass    = "class"
+	nodeKeyStyle    = "style"
+	nodeKeyText     = "text"
+	nodeKeyContent  = "content"
+	nodeKeyChildren = "children"
+	nodeKeyAttrs    = "attrs"
+)
+
+// layoutDocument is the YAML/JSON representation of a Layout
+//
+//	styles:
+//	  - selector: .title
+//	    css: "font-size: 20; font-weight: bold"
+//	root:
+//	  type: VBox
+//	  children:
+//	    - type: Label
+//	      class: title
+//	      content: Hello
+type layoutDocument struct {
+	Styles []styleDocument `yaml:"styles" json:"styles"`
+	Root   map[string]any  `yaml:"root" json:"root"`
+}
+
+// styleDocument is the YAML/JSON representation of a Style.
+// Properties may be given as a map, as a raw CSS string, or both.
+type styleDocument struct {
+	Selector   string            `yaml:"selector" json:"selector"`
+	CSS        string            `yaml:"css" json:"css"`
+	Properties map[string]string `yaml:"properties" json:"properties"`
+}
+
+// DetectLayoutFormat returns the layout format for a file path based on its extension.
+// Unknown extensions default to XML.
+func DetectLayoutFormat(path string) LayoutFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".json":
+		return FormatJSON
+	default:
+		return FormatXML
+	}
+}
+
+// ParseLayout parses a layout from a reader using the given format
+func ParseLayout(r io.Reader, format LayoutFormat) (*Layout, error) {
+	switch format {
+	case FormatYAML:
+		return ParseLayoutYAML(r)
+	case FormatJSON:
+		return ParseLayoutJSON(r)
+	case FormatXML, "":
+		return ParseLayoutXML(r)
+	default:
+		return nil, fmt.Errorf("unsupported layout format: %s", format)
+	}
+}
+
+// ParseLayoutFile parses a layout file, detecting the format from its extension
+func ParseLayoutFile(path string) (*Layout, error) {
+	file, err := os.Open(path) //nolint:gosec // Filepath is from user config, intentional
+	if err != nil {
+		return nil, fmt.Errorf("failed to open layout file: %w", err)
+	}
+	defer func() {
+		_ = file.Close() //nolint:errcheck // Defer close error can be ignored
+	}()
+
+	return ParseLayout(file, DetectLayoutFormat(path))
+}
+
+// ParseLayoutXML parses a layout from an XML reader
+func ParseLayoutXML(r io.Reader) (*Layout, error) {
+	var layout Layout
+	decoder := xml.NewDecoder(r)
+	if err := decoder.Decode(&layout); err != nil {
+		return nil, fmt.Errorf("XML parsing error: %w", err)
+	}
+
+	for i := range layout.Styles {
+		layout.Styles[i].Properties = ParseCSS(layout.Styles[i].RawCSS)
+	}
+
+	return &layout, nil
+}
+
+// ParseLayoutYAML parses a layout from a YAML reader
+func ParseLayoutYAML(r io.Reader) (*Layout, error) {
+	var doc layoutDocument
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("YAML parsing error: %w", err)
+	}
+	return doc.toLayout()
+}
+
+// ParseLayoutJSON parses a layout from a JSON reader
+func ParseLayoutJSON(r io.Reader) (*Layout, error) {
+	var doc layoutDocument
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("JSON parsing error: %w", err)
+	}
+	return doc.toLayout()
+}
+
+// toLayout converts a decoded document into a Layout
+func (doc *layoutDocument) toLayout() (*Layout, error) {
+	if doc.Root == nil {
+		return nil, fmt.Errorf("layout has no root element")
+	}
+
+	root, err := elementFromNode(doc.Root, "root")
+	if err != nil {
+		return nil, err
+	}
+
+	layout := &Layout{
+		XMLName: xml.Name{Local: "Layout"},
+		Root:    root,
+	}
+
+	for _, s := range doc.Styles {
+		props := ParseCSS(s.CSS)
+		for k, v := range s.Properties {
+			props[k] = v
+		}
+		layout.Styles = append(layout.Styles, Style{
+			Selector:   s.Selector,
+			Properties: props,
+			RawCSS:     s.CSS,
+		})
+	}
+
+	return layout, nil
+}
+
+// elementFromNode converts a generic YAML/JSON node into an Element
+func elementFromNode(node map[string]any, path string) (Element, error) {
+	var elem Element
+
+	typeName, ok := node[nodeKeyType].(string)
+	if !ok || typeName == "" {
+		return elem, fmt.Errorf("%s: missing element type", path)
+	}
+	elem.XMLName = xml.Name{Local: typeName}
+
+	// Sort keys so attribute order is deterministic
+	keys := make([]string, 0, len(node))
+	for k := range node {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := node[key]
+		switch key {
+		case nodeKeyType:
+			continue
+		case nodeKeyID:
+			elem.ID = scalarString(value)
+		case nodeKeyClass:
+			elem.Class = scalarString(value)
+		case nodeKeyStyle:
+			elem.Style = scalarString(value)
+		case nodeKeyText:
+			elem.Text = scalarString(value)
+		case nodeKeyContent:
+			elem.Content = scalarString(value)
+		case nodeKeyAttrs:
+			attrs, ok := value.(map[string]any)
+			if !ok {
+				return elem, fmt.Errorf("%s.%s: expected a map", path, key)
+			}
+			attrKeys := make([]string, 0, len(attrs))
+			for k := range attrs {
+				attrKeys = append(attrKeys, k)
+			}
+			sort.Strings(attrKeys)
+			for _, k := range attrKeys {
+				elem.Attributes = append(elem.Attributes, xml.Attr{Name: xml.Name{Local: k}, Value: scalarString(attrs[k])})
+			}
+		case nodeKeyChildren:
+			children, ok := value.([]any)
+			if !ok {
+				return elem, fmt.Errorf("%s.%s: expected a list", path, key)
+			}
+			for i, c := range children {
+				childNode, ok := c.(map[string]any)
+				if !ok {
+					return elem, fmt.Errorf("%s.%s[%d]: expected a map", path, key, i)
+				}
+				child, err := elementFromNode(childNode, fmt.Sprintf("%s.%s[%d]", path, key, i))
+				if err != nil {
+					return elem, err
+				}
+				elem.Children = append(elem.Children, child)
+			}
+		default:
+			switch value.(type) {
+			case map[string]any, []any:
+				return elem, fmt.Errorf("%s.%s: attribute must be a scalar", path, key)
+			}
+			elem.Attributes = append(elem.Attributes, xml.Attr{Name: xml.Name{Local: key}, Value: scalarString(value)})
+		}
+	}
+
+	return elem, nil
+}
+
+// scalarString formats a scalar YAML/JSON value as an attribute string
+func scalarString(value any) string {
+	if value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadLayoutYAML(t *testing.T) {
+	layoutYAML := `
+styles:
+  - selector: .title
+    css: "font-size: 20; font-weight: bold"
+  - selector: "#okBtn"
+    properties:
+      width: 120px
+root:
+  type: VBox
+  children:
+    - type: Label
+      id: title
+      class: title
+      content: Hello
+    - type: Entry
+      id: name
+      placeholder: Your name
+      multiline: true
+    - type: Button
+      id: okBtn
+      text: OK
+      onclick: onOk
+`
+
+	builder := NewBuilder()
+	layout, err := builder.LoadLayoutYAML(strings.NewReader(layoutYAML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+
+	if layout.Root.XMLName.Local != "VBox" {
+		t.Errorf("Expected root VBox, got %s", layout.Root.XMLName.Local)
+	}
+	if len(layout.Root.Children) != 3 {
+		t.Fatalf("Expected 3 children, got %d", len(layout.Root.Children))
+	}
+
+	entry := layout.Root.Children[1]
+	if entry.GetAttr("placeholder") != "Your name" {
+		t.Errorf("Expected placeholder attribute, got %q", entry.GetAttr("placeholder"))
+	}
+	if entry.GetAttr("multiline") != "true" {
+		t.Errorf("Expected multiline=true, got %q", entry.GetAttr("multiline"))
+	}
+
+	if builder.GetStyles()[".title"].Properties["font-size"] != "20" {
+		t.Errorf("Expected .title font-size 20, got %q", builder.GetStyles()[".title"].Properties["font-size"])
+	}
+	if builder.GetStyles()["#okBtn"].Properties["width"] != "120px" {
+		t.Errorf("Expected #okBtn width 120px, got %q", builder.GetStyles()["#okBtn"].Properties["width"])
+	}
+	if layout.Root.Children[0].Content != "Hello" {
+		t.Errorf("Expected content Hello, got %q", layout.Root.Children[0].Content)
+	}
+}
+
+func TestLoadLayoutJSON(t *testing.T) {
+	layoutJSON := `{
+		"styles": [{"selector": ".wide", "css": "width: 300"}],
+		"root": {
+			"type": "HBox",
+			"children": [
+				{"type": "Slider", "id": "vol", "min": 0, "max": 10, "attrs": {"step": 0.5}},
+				{"type": "Label", "class": "wide", "text": "Volume"}
+			]
+		}
+	}`
+
+	builder := NewBuilder()
+	layout, err := builder.LoadLayoutJSON(strings.NewReader(layoutJSON))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+
+	slider := layout.Root.Children[0]
+	if slider.GetAttr("max") != "10" || slider.GetAttr("step") != "0.5" {
+		t.Errorf("Unexpected slider attributes: %v", slider.Attributes)
+	}
+	if layout.Root.Children[1].Text != "Volume" {
+		t.Errorf("Expected text Volume, got %q", layout.Root.Children[1].Text)
+	}
+	if builder.GetStyles()[".wide"].Properties["width"] != "300" {
+		t.Errorf("Expected .wide width 300, got %q", builder.GetStyles()[".wide"].Properties["width"])
+	}
+}
+
+func TestLoadLayoutDocumentErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"Missing root", `{"styles": []}`},
+		{"Missing type", `{"root": {"id": "x"}}`},
+		{"Children not a list", `{"root": {"type": "VBox", "children": {"type": "Label"}}}`},
+		{"Nested attribute", `{"root": {"type": "Label", "foo": {"bar": 1}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewBuilder().LoadLayoutJSON(strings.NewReader(tt.json)); err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
+
+func TestParseLayoutFileDetectsFormat(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"layout.xml":  `<Layout><Label id="lbl">XML</Label></Layout>`,
+		"layout.yaml": "root:\n  type: Label\n  id: lbl\n  content: YAML\n",
+		"layout.json": `{"root": {"type": "Label", "id": "lbl", "content": "JSON"}}`,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+
+		layout, err := ParseLayoutFile(path)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", name, err)
+		}
+
+		root := layout.Root
+		if root.XMLName.Local != "Label" || root.ID != "lbl" {
+			t.Fatalf("%s: unexpected root %s#%s", name, root.XMLName.Local, root.ID)
+		}
+		want := strings.ToUpper(strings.TrimPrefix(filepath.Ext(name), "."))
+		if root.Content != want {
+			t.Errorf("%s: expected content %q, got %q", name, want, root.Content)
+		}
+	}
+}
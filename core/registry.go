@@ -0,0 +1,67 @@
+package core
+
+import (
+	"sort"
+	"sync"
+
+	"fyne.io/fyne/v2"
+)
+
+// ElementFactory builds the Fyne object for a layout element.
+// The builder is passed so factories can build children, look up styles
+// and register the objects they create.
+type ElementFactory func(b *Builder, elem Element, style map[string]string) (fyne.CanvasObject, error)
+
+var (
+	registryMutex sync.RWMutex
+	registry      = make(map[string]ElementFactory)
+)
+
+// Register registers a factory for an element type in the global registry.
+// Widget packages typically call it from an init function; every builder
+// falls back to the global registry when it has no factory of its own.
+func Register(typeName string, factory ElementFactory) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	registry[typeName] = factory
+}
+
+// RegisteredTypes returns the element types in the global registry, sorted by name
+func RegisteredTypes() []string {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	types := make([]string, 0, len(registry))
+	for name := range registry {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// lookupFactory returns the global factory for an element type
+func lookupFactory(typeName string) (ElementFactory, bool) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	factory, ok := registry[typeName]
+	return factory, ok
+}
+
+// RegisterFactory registers a factory for an element type on this builder only.
+// Builder factories take precedence over the global registry.
+func (b *Builder) RegisterFactory(typeName string, factory ElementFactory) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.factories[typeName] = factory
+}
+
+// Factory returns the factory used by this builder for an element type
+func (b *Builder) Factory(typeName string) (ElementFactory, bool) {
+	b.mu.RLock()
+	factory, ok := b.factories[typeName]
+	b.mu.RUnlock()
+	if ok {
+		return factory, true
+	}
+	return lookupFactory(typeName)
+}
@@ -0,0 +1,87 @@
+package core
+
+import "fyne.io/fyne/v2"
+
+// ApplyMinSize applies width/height/min-width/min-height styles by wrapping the object if needed
+func ApplyMinSize(obj fyne.CanvasObject, style map[string]string) fyne.CanvasObject {
+	var width, height float32
+	hasWidth := false
+	hasHeight := false
+
+	// Check for width or min-width
+	if w := style["width"]; w != "" {
+		if parsed, err := ParseSize(w); err == nil {
+			width = parsed
+			hasWidth = true
+		}
+	} else if w := style["min-width"]; w != "" {
+		if parsed, err := ParseSize(w); err == nil {
+			width = parsed
+			hasWidth = true
+		}
+	}
+
+	// Check for height or min-height
+	if h := style["height"]; h != "" {
+		if parsed, err := ParseSize(h); err == nil {
+			height = parsed
+			hasHeight = true
+		}
+	} else if h := style["min-height"]; h != "" {
+		if parsed, err := ParseSize(h); err == nil {
+			height = parsed
+			hasHeight = true
+		}
+	}
+
+	if !hasWidth && !hasHeight {
+		return obj
+	}
+
+	// For objects with SetMinSize method (canvas objects)
+	if sizable, ok := obj.(interface{ SetMinSize(fyne.Size) }); ok {
+		currentSize := obj.MinSize()
+		if !hasWidth {
+			width = currentSize.Width
+		}
+		if !hasHeight {
+			height = currentSize.Height
+		}
+		sizable.SetMinSize(fyne.NewSize(width, height))
+		return obj
+	}
+
+	// For widgets, we need to use a container with min size
+	currentSize := obj.MinSize()
+	if !hasWidth {
+		width = currentSize.Width
+	}
+	if !hasHeight {
+		height = currentSize.Height
+	}
+
+	// Use a MaxLayout container with a sized rectangle behind
+	rect := &fyne.Container{}
+	rect.Resize(fyne.NewSize(width, height))
+
+	return &fyne.Container{
+		Layout:  &fixedSizeLayout{size: fyne.NewSize(width, height)},
+		Objects: []fyne.CanvasObject{obj},
+	}
+}
+
+// fixedSizeLayout is a simple layout that enforces a minimum size
+type fixedSizeLayout struct {
+	size fyne.Size
+}
+
+func (f *fixedSizeLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	return f.size
+}
+
+func (f *fixedSizeLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	for _, obj := range objects {
+		obj.Resize(size)
+		obj.Move(fyne.NewPos(0, 0))
+	}
+}
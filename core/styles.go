@@ -0,0 +1,116 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseCSS parses a CSS declaration block and returns a property map
+func ParseCSS(css string) map[string]string {
+	props := make(map[string]string)
+
+	// Remove surrounding braces
+	css = strings.TrimSpace(css)
+	css = strings.Trim(css, "{}")
+
+	// Split declarations on semicolons
+	declarations := strings.Split(css, ";")
+	for _, decl := range declarations {
+		decl = strings.TrimSpace(decl)
+		if decl == "" {
+			continue
+		}
+
+		parts := strings.SplitN(decl, ":", 2)
+		if len(parts) == 2 {
+			key := strings.TrimSpace(parts[0])
+			value := strings.TrimSpace(parts[1])
+			props[key] = value
+		}
+	}
+
+	return props
+}
+
+// ParseSize converts a size string (e.g. "100", "100px") to float32
+func ParseSize(sizeStr string) (float32, error) {
+	sizeStr = strings.TrimSpace(sizeStr)
+
+	// Strip the optional "px" unit
+	sizeStr = strings.TrimSuffix(sizeStr, "px")
+
+	val, err := strconv.ParseFloat(sizeStr, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return float32(val), nil
+}
+
+// applyClassStyles applies CSS class styles to the style map
+func (b *Builder) applyClassStyles(style *map[string]string, classes string) {
+	if classes == "" {
+		return
+	}
+
+	classList := strings.Split(classes, " ")
+	for _, class := range classList {
+		class = strings.TrimSpace(class)
+		if class == "" {
+			continue
+		}
+
+		selector := "." + class
+		if s, ok := b.target().styles[selector]; ok {
+			for k, v := range s.Properties {
+				(*style)[k] = v
+			}
+		}
+	}
+}
+
+// applyIDStyles applies CSS ID styles to the style map
+func (b *Builder) applyIDStyles(style *map[string]string, id string) {
+	if id == "" {
+		return
+	}
+
+	selector := "#" + id
+	if s, ok := b.target().styles[selector]; ok {
+		for k, v := range s.Properties {
+			(*style)[k] = v
+		}
+	}
+}
+
+// applyInlineStyles applies inline CSS styles to the style map
+func (b *Builder) applyInlineStyles(style *map[string]string, inlineStyle string) {
+	if inlineStyle == "" {
+		return
+	}
+
+	inlineStyles := ParseCSS(inlineStyle)
+	for k, v := range inlineStyles {
+		(*style)[k] = v
+	}
+}
+
+// ComputeStyle calculates the final style for an element
+// This applies styles in order of precedence: class < id < inline
+func (b *Builder) ComputeStyle(elem Element) map[string]string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	style := make(map[string]string)
+
+	// Apply styles from classes (lowest precedence)
+	b.applyClassStyles(&style, elem.Class)
+
+	// Apply styles from ID (medium precedence)
+	b.applyIDStyles(&style, elem.ID)
+
+	// Apply inline styles (highest precedence)
+	b.applyInlineStyles(&style, elem.Style)
+
+	return style
+}
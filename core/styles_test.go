@@ -0,0 +1,235 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseCSS(t *testing.T) {
+	tests := []struct {
+		name     string
+		css      string
+		expected map[string]string
+	}{
+		{
+			name: "Simple CSS",
+			css:  "font-size: 16; color: red;",
+			expected: map[string]string{
+				"font-size": "16",
+				"color":     "red",
+			},
+		},
+		{
+			name: "CSS with braces",
+			css:  "{ font-weight: bold; text-align: center; }",
+			expected: map[string]string{
+				"font-weight": "bold",
+				"text-align":  "center",
+			},
+		},
+		{
+			name: "CSS with spaces",
+			css:  "  background-color: #FF0000  ;  width: 300  ",
+			expected: map[string]string{
+				"background-color": "#FF0000",
+				"width":            "300",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseCSS(tt.css)
+
+			if len(result) != len(tt.expected) {
+				t.Errorf("Expected %d properties, got %d", len(tt.expected), len(result))
+			}
+
+			for key, expectedValue := range tt.expected {
+				if result[key] != expectedValue {
+					t.Errorf("For key %s: expected %s, got %s", key, expectedValue, result[key])
+				}
+			}
+		})
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected float32
+		hasError bool
+	}{
+		{"Simple number", "100", 100, false},
+		{"With px", "200px", 200, false},
+		{"Float", "15.5", 15.5, false},
+		{"With spaces", "  50  ", 50, false},
+		{"Invalid", "abc", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSize(tt.input)
+
+			if tt.hasError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				if result != tt.expected {
+					t.Errorf("Expected %f, got %f", tt.expected, result)
+				}
+			}
+		})
+	}
+}
+
+// TestStyleCascade verifica la precedenza degli stili (class < id < inline)
+func TestStyleCascade(t *testing.T) {
+	xml := `
+		<Layout>
+			<Style selector=".red-text">color: red;</Style>
+			<Style selector="#special">color: blue;</Style>
+			<VBox class="red-text" id="special" style="color: green;">
+				<Label>Test</Label>
+			</VBox>
+		</Layout>
+	`
+
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(bytes.NewReader([]byte(xml)))
+	if err != nil {
+		t.Fatalf("Failed to parse layout: %v", err)
+	}
+
+	// Test cascade: inline > id > class
+	elem := layout.Root
+	style := builder.ComputeStyle(elem)
+
+	// Inline style should win
+	if style["color"] != "green" {
+		t.Errorf("Expected inline style 'green', got '%s'", style["color"])
+	}
+
+	// Test without inline style - ID should win
+	elem.Style = ""
+	style = builder.ComputeStyle(elem)
+	if style["color"] != "blue" {
+		t.Errorf("Expected ID style 'blue', got '%s'", style["color"])
+	}
+
+	// Test without inline and ID - class should apply
+	elem.ID = ""
+	style = builder.ComputeStyle(elem)
+	if style["color"] != "red" {
+		t.Errorf("Expected class style 'red', got '%s'", style["color"])
+	}
+}
+
+// TestApplyStyleMethods tests individual style application methods
+func TestApplyStyleMethods(t *testing.T) {
+	builder := NewBuilder()
+
+	// Setup test styles
+	builder.state.styles = map[string]Style{
+		".red": {
+			Selector:   ".red",
+			Properties: map[string]string{"color": "red", "font-size": "14"},
+		},
+		".large": {
+			Selector:   ".large",
+			Properties: map[string]string{"font-size": "20"},
+		},
+		"#unique": {
+			Selector:   "#unique",
+			Properties: map[string]string{"color": "blue"},
+		},
+	}
+
+	t.Run("applyClassStyles - single class", func(t *testing.T) {
+		style := make(map[string]string)
+		builder.applyClassStyles(&style, "red")
+
+		if style["color"] != "red" {
+			t.Errorf("Expected color 'red', got '%s'", style["color"])
+		}
+		if style["font-size"] != "14" {
+			t.Errorf("Expected font-size '14', got '%s'", style["font-size"])
+		}
+	})
+
+	t.Run("applyClassStyles - multiple classes", func(t *testing.T) {
+		style := make(map[string]string)
+		builder.applyClassStyles(&style, "red large")
+
+		// 'large' should override 'red' font-size
+		if style["color"] != "red" {
+			t.Errorf("Expected color 'red', got '%s'", style["color"])
+		}
+		if style["font-size"] != "20" {
+			t.Errorf("Expected font-size '20', got '%s'", style["font-size"])
+		}
+	})
+
+	t.Run("applyClassStyles - empty", func(t *testing.T) {
+		style := make(map[string]string)
+		builder.applyClassStyles(&style, "")
+
+		if len(style) != 0 {
+			t.Errorf("Expected empty style, got %v", style)
+		}
+	})
+
+	t.Run("applyIDStyles", func(t *testing.T) {
+		style := make(map[string]string)
+		builder.applyIDStyles(&style, "unique")
+
+		if style["color"] != "blue" {
+			t.Errorf("Expected color 'blue', got '%s'", style["color"])
+		}
+	})
+
+	t.Run("applyIDStyles - empty", func(t *testing.T) {
+		style := make(map[string]string)
+		builder.applyIDStyles(&style, "")
+
+		if len(style) != 0 {
+			t.Errorf("Expected empty style, got %v", style)
+		}
+	})
+
+	t.Run("applyInlineStyles", func(t *testing.T) {
+		style := make(map[string]string)
+		builder.applyInlineStyles(&style, "color: green; font-weight: bold")
+
+		if style["color"] != "green" {
+			t.Errorf("Expected color 'green', got '%s'", style["color"])
+		}
+		if style["font-weight"] != "bold" {
+			t.Errorf("Expected font-weight 'bold', got '%s'", style["font-weight"])
+		}
+	})
+
+	t.Run("applyInlineStyles - empty", func(t *testing.T) {
+		style := make(map[string]string)
+		builder.applyInlineStyles(&style, "")
+
+		if len(style) != 0 {
+			t.Errorf("Expected empty style, got %v", style)
+		}
+	})
+}
+
+// BenchmarkParseCSS measures performance of CSS parsing
+func BenchmarkParseCSS(b *testing.B) {
+	css := "color: red; font-size: 14px; padding: 10px; margin: 5px; background: white; border: 1px solid black;"
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ParseCSS(css)
+	}
+}
@@ -0,0 +1,78 @@
+package fylay
+
+import "fmt"
+
+// Diagnostic descrive un problema nel collegamento tra layout e codice,
+// ad esempio un handler usato nel layout ma mai registrato
+type Diagnostic struct {
+	ElementID string // ID dell'elemento coinvolto, se presente
+	Element   string // Tipo dell'elemento coinvolto, es. "Button"
+	Attr      string // Attributo coinvolto, es. "onclick"
+	Handler   string // Nome dell'handler o del campo coinvolto
+	Message   string
+}
+
+// String restituisce una descrizione leggibile della diagnostica
+func (d Diagnostic) String() string {
+	where := d.Element
+	if d.ElementID != "" {
+		where += "#" + d.ElementID
+	}
+	if d.Attr != "" {
+		where += " " + d.Attr
+	}
+	if where == "" {
+		return d.Message
+	}
+	return fmt.Sprintf("%s: %s", where, d.Message)
+}
+
+// eventReference è un attributo evento trovato nel layout
+type eventReference struct {
+	elem *Element
+	attr string
+	expr handlerExpr
+	err  error // Errore di parsing dell'espressione
+}
+
+// eventReferences restituisce gli attributi evento dell'ultimo layout costruito
+func (b *Builder) eventReferences() []eventReference {
+	return layoutEventReferences(b.Layout())
+}
+
+// layoutEventReferences restituisce gli attributi evento di un layout
+func layoutEventReferences(layout *Layout) []eventReference {
+	if layout == nil {
+		return nil
+	}
+
+	var refs []eventReference
+	var walk func(elem *Element)
+	walk = func(elem *Element) {
+		for _, attr := range eventAttributes {
+			value := elem.GetAttr(attr)
+			if value == "" {
+				continue
+			}
+			expr, err := parseHandlerExpr(value)
+			refs = append(refs, eventReference{elem: elem, attr: attr, expr: expr, err: err})
+		}
+		for i := range elem.Children {
+			walk(&elem.Children[i])
+		}
+	}
+	walk(&layout.Root)
+
+	return refs
+}
+
+// diagnostic crea una diagnostica per un riferimento a un evento
+func (r eventReference) diagnostic(format string, args ...any) Diagnostic {
+	return Diagnostic{
+		ElementID: r.elem.ID,
+		Element:   r.elem.XMLName.Local,
+		Attr:      r.attr,
+		Handler:   r.expr.name,
+		Message:   fmt.Sprintf(format, args...),
+	}
+}
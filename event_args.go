@@ -0,0 +1,232 @@
+package fylay
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// handlerExpr è un attributo evento parsato, es. deleteRow(42, 'draft')
+type handlerExpr struct {
+	name string
+	args []argExpr
+}
+
+// argExpr è un argomento di un handler: un letterale oppure un identificatore
+// (chiave di binding o variabile di template, anche con percorso es. item.id)
+type argExpr struct {
+	literal any
+	path    string
+}
+
+// parseHandlerExpr parsa un attributo evento.
+// Senza parentesi l'intero attributo è il nome dell'handler.
+func parseHandlerExpr(attr string) (handlerExpr, error) {
+	attr = strings.TrimSpace(attr)
+
+	open := strings.IndexByte(attr, '(')
+	if open < 0 {
+		return handlerExpr{name: attr}, nil
+	}
+
+	expr := handlerExpr{name: strings.TrimSpace(attr[:open])}
+	if expr.name == "" {
+		return expr, fmt.Errorf("missing handler name in %q", attr)
+	}
+	if !strings.HasSuffix(attr, ")") {
+		return expr, fmt.Errorf("missing closing parenthesis in %q", attr)
+	}
+
+	args, err := splitArgs(attr[open+1 : len(attr)-1])
+	if err != nil {
+		return expr, fmt.Errorf("invalid arguments in %q: %w", attr, err)
+	}
+
+	for _, raw := range args {
+		arg, err := parseArg(raw)
+		if err != nil {
+			return expr, fmt.Errorf("invalid arguments in %q: %w", attr, err)
+		}
+		expr.args = append(expr.args, arg)
+	}
+
+	return expr, nil
+}
+
+// splitArgs divide la lista di argomenti sulle virgole esterne alle stringhe
+func splitArgs(list string) ([]string, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+
+	var args []string
+	var quote rune
+	escaped := false
+	start := 0
+
+	for i, r := range list {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0 && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ',':
+			args = append(args, strings.TrimSpace(list[start:i]))
+			start = i + 1
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated string")
+	}
+
+	return append(args, strings.TrimSpace(list[start:])), nil
+}
+
+// parseArg parsa un singolo argomento
+func parseArg(raw string) (argExpr, error) {
+	if raw == "" {
+		return argExpr{}, fmt.Errorf("empty argument")
+	}
+
+	switch raw {
+	case "true":
+		return argExpr{literal: true}, nil
+	case "false":
+		return argExpr{literal: false}, nil
+	case "null", "nil":
+		return argExpr{}, nil
+	}
+
+	// Stringhe tra apici singoli o doppi
+	if quote := raw[0]; quote == '\'' || quote == '"' {
+		if len(raw) < 2 || raw[len(raw)-1] != quote {
+			return argExpr{}, fmt.Errorf("malformed string %s", raw)
+		}
+		return argExpr{literal: unescapeArg(raw[1 : len(raw)-1])}, nil
+	}
+
+	// Numeri: interi come int, gli altri come float64
+	if i, err := strconv.Atoi(raw); err == nil {
+		return argExpr{literal: i}, nil
+	}
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		return argExpr{literal: f}, nil
+	}
+
+	if !isPath(raw) {
+		return argExpr{}, fmt.Errorf("unexpected argument %s", raw)
+	}
+	return argExpr{path: raw}, nil
+}
+
+// unescapeArg rimuove i backslash di escape da una stringa
+func unescapeArg(s string) string {
+	var sb strings.Builder
+	escaped := false
+	for _, r := range s {
+		if !escaped && r == '\\' {
+			escaped = true
+			continue
+		}
+		escaped = false
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// isPath verifica che un argomento sia un identificatore, eventualmente con percorso
+func isPath(s string) bool {
+	for _, part := range strings.Split(s, ".") {
+		if part == "" || unicode.IsDigit(rune(part[0])) {
+			return false
+		}
+		for _, r := range part {
+			if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// evalArgs valuta gli argomenti di un handler al momento dell'evento.
+// Gli identificatori sono cercati prima tra le chiavi di binding, poi tra le
+// variabili di template; quelli non risolti valgono nil.
+func (b *Builder) evalArgs(args []argExpr) []any {
+	if len(args) == 0 {
+		return nil
+	}
+
+	values := make([]any, len(args))
+	for i, arg := range args {
+		if arg.path == "" {
+			values[i] = arg.literal
+			continue
+		}
+		values[i] = b.resolvePath(arg.path)
+	}
+	return values
+}
+
+// resolvePath risolve un identificatore come item.id
+func (b *Builder) resolvePath(path string) any {
+	if value, err := b.GetBindingContext().GetValue(path); err == nil {
+		return value
+	}
+
+	parts := strings.Split(path, ".")
+	value, ok := b.GetTemplateContext().GetVariable(parts[0])
+	if !ok {
+		return nil
+	}
+
+	for _, field := range parts[1:] {
+		if value, ok = lookupField(value, field); !ok {
+			return nil
+		}
+	}
+	return value
+}
+
+// lookupField legge una chiave di una mappa o un campo esportato di una struct.
+// Il nome del campo è confrontato senza distinguere maiuscole e minuscole.
+func lookupField(value any, field string) (any, bool) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		item := v.MapIndex(reflect.ValueOf(field).Convert(v.Type().Key()))
+		if !item.IsValid() {
+			return nil, false
+		}
+		return item.Interface(), true
+	case reflect.Struct:
+		f := v.FieldByNameFunc(func(name string) bool {
+			return strings.EqualFold(name, field)
+		})
+		if !f.IsValid() || !f.CanInterface() {
+			return nil, false
+		}
+		return f.Interface(), true
+	default:
+		return nil, false
+	}
+}
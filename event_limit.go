@@ -0,0 +1,172 @@
+package fylay
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// Suffissi degli attributi che limitano la frequenza di un evento,
+// es. onchange-debounce="300ms" o onchange-throttle="100ms"
+const (
+	suffixDebounce = "-debounce"
+	suffixThrottle = "-throttle"
+)
+
+// limiterKey identifica un evento di un elemento costruito
+type limiterKey struct {
+	elem *Element
+	attr string
+}
+
+// eventLimiter rimanda le invocazioni di un evento con debounce o throttle.
+// Le invocazioni rimandate vengono eseguite sul thread di Fyne.
+type eventLimiter struct {
+	mu       sync.Mutex
+	timer    *time.Timer
+	pending  func()    // Ultima invocazione in attesa
+	last     time.Time // Ultima invocazione eseguita (throttle)
+	gen      uint64    // Programmazione corrente, per scartare i flush superati
+	canceled bool
+}
+
+// debounce esegue fn solo dopo che sono trascorsi delay dall'ultima chiamata
+func (l *eventLimiter) debounce(delay time.Duration, fn func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.pending = fn
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+	l.scheduleLocked(delay)
+}
+
+// throttle esegue fn al massimo una volta ogni interval. La prima chiamata è
+// eseguita subito, l'ultima di un intervallo alla sua scadenza.
+func (l *eventLimiter) throttle(interval time.Duration, fn func()) {
+	l.mu.Lock()
+
+	if l.timer == nil {
+		if wait := interval - time.Since(l.last); wait > 0 {
+			l.pending = fn
+			l.scheduleLocked(wait)
+			l.mu.Unlock()
+			return
+		}
+		l.last = time.Now()
+		l.mu.Unlock()
+		fn()
+		return
+	}
+
+	// Un'invocazione è già programmata: viene sostituita dalla più recente
+	l.pending = fn
+	l.mu.Unlock()
+}
+
+// scheduleLocked programma il flush dopo delay. Stop non annulla un flush già
+// accodato sul thread di Fyne: ognuno porta la sua programmazione ed esegue
+// solo se è ancora quella corrente. Il chiamante deve possedere l.mu.
+func (l *eventLimiter) scheduleLocked(delay time.Duration) {
+	l.gen++
+	gen := l.gen
+	l.timer = time.AfterFunc(delay, func() {
+		fyne.Do(func() { l.flush(gen) })
+	})
+}
+
+// flush esegue l'invocazione in attesa della programmazione gen, sul thread
+// di Fyne
+func (l *eventLimiter) flush(gen uint64) {
+	l.mu.Lock()
+	if gen != l.gen {
+		l.mu.Unlock()
+		return
+	}
+	fn := l.pending
+	l.pending = nil
+	l.timer = nil
+	l.last = time.Now()
+	canceled := l.canceled
+	l.mu.Unlock()
+
+	if fn != nil && !canceled {
+		fn()
+	}
+}
+
+// cancel annulla l'invocazione in attesa e le successive
+func (l *eventLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.canceled = true
+	l.pending = nil
+	l.gen++
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
+}
+
+// limitEvent applica debounce o throttle all'evento se l'elemento li
+// richiede. Restituisce false se l'evento va invocato subito.
+func (b *Builder) limitEvent(attr string, elem *Element, fn func()) bool {
+	if d, ok := parseEventDelay(elem.GetAttr(attr + suffixDebounce)); ok {
+		b.limiter(attr, elem).debounce(d, fn)
+		return true
+	}
+
+	if d, ok := parseEventDelay(elem.GetAttr(attr + suffixThrottle)); ok {
+		b.limiter(attr, elem).throttle(d, fn)
+		return true
+	}
+
+	return false
+}
+
+// limiter restituisce il limitatore di un evento, creandolo se necessario
+func (b *Builder) limiter(attr string, elem *Element) *eventLimiter {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := limiterKey{elem: elem, attr: attr}
+	l, ok := b.limiters[key]
+	if !ok {
+		l = &eventLimiter{}
+		b.limiters[key] = l
+	}
+	return l
+}
+
+// cancelPendingEvents annulla gli eventi rimandati degli elementi costruiti
+func (b *Builder) cancelPendingEvents() {
+	b.mu.Lock()
+	limiters := b.limiters
+	b.limiters = make(map[limiterKey]*eventLimiter)
+	b.mu.Unlock()
+
+	for _, l := range limiters {
+		l.cancel()
+	}
+}
+
+// parseEventDelay interpreta una durata come "300ms" o "1s".
+// Un numero senza unità è espresso in millisecondi.
+func parseEventDelay(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if ms, err := strconv.Atoi(value); err == nil {
+		return time.Duration(ms) * time.Millisecond, ms > 0
+	}
+
+	d, err := time.ParseDuration(value)
+	return d, err == nil && d > 0
+}
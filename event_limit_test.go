@@ -0,0 +1,171 @@
+package fylay
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+// buildLimitedEntry builds a layout with a single Entry using the given attributes
+func buildLimitedEntry(t *testing.T, attrs string) (*Builder, *Layout, *widget.Entry) {
+	t.Helper()
+	_ = test.NewApp()
+
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(`<Layout><Entry id="search" onchange="onSearch" ` + attrs + ` /></Layout>`))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+	return builder, layout, builder.GetWidget("search").(*widget.Entry)
+}
+
+// eventRecorder records the values of the events it receives
+type eventRecorder struct {
+	mu     sync.Mutex
+	values []string
+}
+
+func (r *eventRecorder) record(ctx *EventContext) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values = append(r.values, ctx.Value)
+}
+
+func (r *eventRecorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.values...)
+}
+
+func TestEventDebounce(t *testing.T) {
+	builder, _, entry := buildLimitedEntry(t, `onchange-debounce="30ms"`)
+	rec := &eventRecorder{}
+	builder.On("onSearch", rec.record)
+
+	entry.SetText("a")
+	entry.SetText("ab")
+	entry.SetText("abc")
+
+	if got := rec.get(); len(got) != 0 {
+		t.Fatalf("Expected no immediate events, got %v", got)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	if got := rec.get(); strings.Join(got, ",") != "abc" {
+		t.Errorf("Expected a single debounced event with the last value, got %v", got)
+	}
+}
+
+func TestEventThrottle(t *testing.T) {
+	builder, _, entry := buildLimitedEntry(t, `onchange-throttle="50"`)
+	rec := &eventRecorder{}
+	builder.On("onSearch", rec.record)
+
+	entry.SetText("a")
+	entry.SetText("ab")
+	entry.SetText("abc")
+
+	if got := rec.get(); strings.Join(got, ",") != "a" {
+		t.Fatalf("Expected the first event to fire immediately, got %v", got)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if got := rec.get(); strings.Join(got, ",") != "a,abc" {
+		t.Errorf("Expected a trailing event with the last value, got %v", got)
+	}
+}
+
+func TestEventLimitCanceledOnReplace(t *testing.T) {
+	builder, layout, entry := buildLimitedEntry(t, `onchange-debounce="30ms"`)
+	rec := &eventRecorder{}
+	builder.On("onSearch", rec.record)
+
+	entry.SetText("stale")
+	if _, err := builder.Rebuild(layout); err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+
+	entry = builder.GetWidget("search").(*widget.Entry)
+	entry.SetText("rebuilt")
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if got := rec.get(); len(got) != 0 {
+		t.Fatalf("Expected Build to cancel pending events, got %v", got)
+	}
+
+	entry = builder.GetWidget("search").(*widget.Entry)
+	entry.SetText("fresh")
+	builder.Reset()
+
+	time.Sleep(100 * time.Millisecond)
+	if got := rec.get(); len(got) != 0 {
+		t.Errorf("Expected pending events to be canceled, got %v", got)
+	}
+}
+
+func TestEventLimitStaleFlush(t *testing.T) {
+	_ = test.NewApp()
+
+	// A flush queued before the next event must not run it early
+	l := &eventLimiter{}
+	fired := ""
+	l.debounce(time.Hour, func() { fired = "first" })
+	stale := l.gen
+	l.debounce(time.Hour, func() { fired = "second" })
+
+	l.flush(stale)
+	if fired != "" {
+		t.Errorf("Expected the stale flush to be skipped, got %q", fired)
+	}
+	if l.timer == nil || l.pending == nil {
+		t.Error("Expected the second event to stay debounced")
+	}
+
+	l.flush(l.gen)
+	if fired != "second" {
+		t.Errorf("Expected the current flush to run the last event, got %q", fired)
+	}
+}
+
+func TestEventLimitFallback(t *testing.T) {
+	builder, _, entry := buildLimitedEntry(t, `onchange-debounce="30ms"`)
+	handler := &testEventHandler{}
+	builder.SetEventHandler(handler)
+
+	// Without a callback the event handler is used right away
+	entry.SetText("ada")
+	if !handler.entryChanged || handler.lastValue != "ada" {
+		t.Errorf("Expected the event handler fallback, got %+v", handler)
+	}
+}
+
+func TestParseEventDelay(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"300ms", 300 * time.Millisecond, true},
+		{"1s", time.Second, true},
+		{"250", 250 * time.Millisecond, true},
+		{"", 0, false},
+		{"0", 0, false},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseEventDelay(tt.value)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("parseEventDelay(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package fylay
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// Attributi evento supportati dai widget predefiniti
+const (
+	eventClick        = "onclick"
+	eventChange       = "onchange"
+	eventSubmit       = "onsubmit"       // Entry con il tasto Enter, Form con il pulsante di invio
+	eventCancel       = "oncancel"       // Form, pulsante di annullamento
+	eventFocus        = "onfocus"        // Qualsiasi elemento
+	eventBlur         = "onblur"         // Qualsiasi elemento
+	eventHover        = "onhover"        // Qualsiasi elemento, ingresso del puntatore
+	eventLeave        = "onleave"        // Qualsiasi elemento, uscita del puntatore
+	eventDoubleTap    = "ondoubletap"    // Qualsiasi elemento
+	eventSecondaryTap = "onsecondarytap" // Qualsiasi elemento, menu contestuale
+	eventKey          = "onkey"          // Qualsiasi elemento con il focus, Value è il nome del tasto
+	eventSelection    = "onselect"       // List, Table e Tree, Value è l'indice o l'ID e Item l'elemento selezionato
+	eventOpen         = "onopen"         // Tree, apertura di un ramo, Value è l'ID e Item il nodo
+	eventClose        = "onclose"        // Tree, chiusura di un ramo, Value è l'ID e Item il nodo
+)
+
+// eventAttributes elenca tutti gli attributi evento riconosciuti
+var eventAttributes = []string{
+	eventClick,
+	eventChange,
+	eventSubmit,
+	eventCancel,
+	eventFocus,
+	eventBlur,
+	eventHover,
+	eventLeave,
+	eventDoubleTap,
+	eventSecondaryTap,
+	eventKey,
+	eventSelection,
+	eventOpen,
+	eventClose,
+}
+
+// EventContext contiene le informazioni di contesto di un evento
+type EventContext struct {
+	// EventName è il nome dell'handler indicato nell'attributo (es. "onSave")
+	EventName string
+	// Type è l'attributo che ha generato l'evento (es. "onclick", "onchange")
+	Type string
+	// Target è l'elemento che ha generato l'evento
+	Target fyne.CanvasObject
+	// TargetID è l'ID dell'elemento che ha generato l'evento
+	TargetID string
+	// Element è l'elemento del layout che ha generato l'evento
+	Element *Element
+	// Builder è il builder che ha costruito l'elemento
+	Builder *Builder
+	// Value è il valore associato all'evento in forma testuale
+	Value string
+	// Args sono gli argomenti indicati nell'attributo, es. deleteRow(42, 'draft'),
+	// valutati al momento dell'evento
+	Args []any
+	// Item è l'elemento selezionato per gli eventi onselect, o il nodo per
+	// onopen e onclose
+	Item any
+	// Values sono i valori dei campi per gli eventi onsubmit di Form,
+	// per nome del campo
+	Values map[string]any
+
+	raw any
+}
+
+// selectionValue è il valore di un evento su un elemento di List, Table o
+// Tree: l'indice o l'ID dell'elemento e l'elemento corrispondente
+type selectionValue struct {
+	id   any
+	item any
+}
+
+// RawValue restituisce il valore tipizzato dell'evento (string, bool, float64, int, []string o nil)
+func (ctx *EventContext) RawValue() any {
+	return ctx.raw
+}
+
+// Arg restituisce l'argomento i-esimo dell'handler, o nil se assente
+func (ctx *EventContext) Arg(i int) any {
+	if i < 0 || i >= len(ctx.Args) {
+		return nil
+	}
+	return ctx.Args[i]
+}
+
+// String restituisce il valore dell'evento come stringa
+func (ctx *EventContext) String() string {
+	return ctx.Value
+}
+
+// Bool restituisce il valore dell'evento come bool (es. per Checkbox)
+func (ctx *EventContext) Bool() bool {
+	switch v := ctx.raw.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	}
+	parsed, err := strconv.ParseBool(ctx.Value)
+	return err == nil && parsed
+}
+
+// Float restituisce il valore dell'evento come float64 (es. per Slider)
+func (ctx *EventContext) Float() float64 {
+	switch v := ctx.raw.(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	}
+	parsed, err := strconv.ParseFloat(ctx.Value, 64)
+	if err != nil {
+		return 0
+	}
+	return parsed
+}
+
+// Int restituisce il valore dell'evento come int
+func (ctx *EventContext) Int() int {
+	return int(ctx.Float())
+}
+
+// EventCallback è una funzione callback per gli eventi dei widget
+type EventCallback func(ctx *EventContext)
+
+// ButtonCallback è una funzione callback per eventi di pulsanti
+//
+// Deprecated: usare EventCallback.
+type ButtonCallback = EventCallback
+
+// EntryCallback è una funzione callback per eventi Entry
+//
+// Deprecated: usare EventCallback.
+type EntryCallback = EventCallback
+
+// EventHandler gestisce gli eventi dei widget
+type EventHandler interface {
+	OnButtonTapped(id string)
+	OnEntryChanged(id, value string)
+}
+
+// SetEventHandler imposta l'handler degli eventi
+func (b *Builder) SetEventHandler(handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.eventHandler = handler
+}
+
+// On registra una callback per un evento.
+// La callback viene cercata quando l'evento si verifica, quindi può essere
+// registrata anche dopo Build. Una callback con il nome di un'azione
+// predefinita, come undo e redo, la sostituisce.
+func (b *Builder) On(eventName string, callback EventCallback) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.callbacks[eventName] = callback
+	delete(b.handlerAliases, eventName)
+}
+
+// OnEntry registra una callback per eventi Entry
+//
+// Deprecated: On gestisce gli eventi di tutti i widget.
+func (b *Builder) OnEntry(eventName string, callback EventCallback) {
+	b.On(eventName, callback)
+}
+
+// getEventHandler restituisce l'handler degli eventi corrente
+func (b *Builder) getEventHandler() EventHandler {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.eventHandler
+}
+
+// builtinActions sono le azioni utilizzabili nel layout senza registrare
+// una callback, ad esempio onclick="undo"
+var builtinActions = map[string]EventCallback{
+	"undo": func(ctx *EventContext) { ctx.Builder.GetBindingContext().Undo() },
+	"redo": func(ctx *EventContext) { ctx.Builder.GetBindingContext().Redo() },
+}
+
+// callback restituisce la callback registrata per un evento, o l'azione
+// predefinita con lo stesso nome
+func (b *Builder) callback(eventName string) (EventCallback, bool) {
+	b.mu.RLock()
+	callback, ok := b.callbacks[eventName]
+	b.mu.RUnlock()
+	if !ok {
+		callback, ok = builtinActions[eventName]
+	}
+	return callback, ok
+}
+
+// hasCallback indica se l'espressione di un handler, es. "save(item)", ha
+// una callback registrata o un'azione predefinita
+func (b *Builder) hasCallback(handler string) bool {
+	expr, err := parseHandlerExpr(handler)
+	if err != nil {
+		return false
+	}
+	_, ok := b.callback(expr.name)
+	return ok
+}
+
+// fireEvent invoca la callback indicata dall'attributo evento dell'elemento.
+// Restituisce false se l'attributo è assente o non valido, o se nessuna
+// callback è registrata. Gli eventi con debounce o throttle sono rimandati
+// e risultano gestiti se la loro callback è registrata.
+func (b *Builder) fireEvent(attr string, elem *Element, target fyne.CanvasObject, value any) bool {
+	if elem.GetAttr(attr) == "" {
+		return false
+	}
+
+	// Gli eventi senza callback non vengono rimandati, così il chiamante può
+	// ricadere subito sull'EventHandler
+	if b.hasCallback(elem.GetAttr(attr)) &&
+		b.limitEvent(attr, elem, func() { b.dispatchEvent(attr, elem, target, value) }) {
+		return true
+	}
+
+	return b.dispatchEvent(attr, elem, target, value)
+}
+
+// dispatchEvent risolve la callback dell'attributo evento e la invoca
+func (b *Builder) dispatchEvent(attr string, elem *Element, target fyne.CanvasObject, value any) bool {
+	var item any
+	var values map[string]any
+	switch v := value.(type) {
+	case selectionValue:
+		value, item = v.id, v.item
+	case formValues:
+		value, values = nil, v
+	}
+
+	ctx := &EventContext{
+		EventName: elem.GetAttr(attr),
+		Type:      attr,
+		Target:    target,
+		TargetID:  elem.ID,
+		Element:   elem,
+		Builder:   b,
+		Value:     formatEventValue(value),
+		Item:      item,
+		Values:    values,
+		raw:       value,
+	}
+
+	expr, err := parseHandlerExpr(ctx.EventName)
+	if err != nil {
+		b.traceEvent(ctx, false)
+		return false
+	}
+	ctx.EventName = expr.name
+	ctx.Args = b.evalArgs(expr.args)
+
+	callback, ok := b.callback(expr.name)
+	b.traceEvent(ctx, ok)
+	if !ok {
+		return false
+	}
+
+	callback(ctx)
+	return true
+}
+
+// formatEventValue converte il valore di un evento nella forma testuale
+func formatEventValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		return strings.Join(v, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,324 @@
+package fylay
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+const eventsLayoutXML = `
+<Layout>
+	<VBox>
+		<Button id="saveBtn" onclick="onSave">Save</Button>
+		<Entry id="nameInput" onchange="onName" />
+		<Checkbox id="agree" onchange="onAgree" label="Agree" />
+		<Slider id="volume" onchange="onVolume" min="0" max="10" step="0.5" />
+		<Select id="color" onchange="onColor">
+			<Option>Red</Option>
+			<Option>Blue</Option>
+		</Select>
+		<RadioGroup id="size" onchange="onSize">
+			<Radio>S</Radio>
+			<Radio>M</Radio>
+		</RadioGroup>
+	</VBox>
+</Layout>
+`
+
+// buildEventsLayout builds the events test layout
+func buildEventsLayout(t *testing.T) *Builder {
+	t.Helper()
+	_ = test.NewApp()
+
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(eventsLayoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+	return builder
+}
+
+func TestEventsRegisteredAfterBuild(t *testing.T) {
+	builder := buildEventsLayout(t)
+
+	events := make(map[string]*EventContext)
+	record := func(ctx *EventContext) {
+		events[ctx.EventName] = ctx
+	}
+	for _, name := range []string{"onSave", "onName", "onAgree", "onVolume", "onColor", "onSize"} {
+		builder.On(name, record)
+	}
+
+	test.Tap(builder.GetWidget("saveBtn").(*widget.Button))
+	builder.GetWidget("nameInput").(*widget.Entry).SetText("Ada")
+	builder.GetWidget("agree").(*widget.Check).SetChecked(true)
+	builder.GetWidget("volume").(*widget.Slider).SetValue(7.5)
+	builder.GetWidget("color").(*widget.Select).SetSelected("Blue")
+	builder.GetWidget("size").(*widget.RadioGroup).SetSelected("M")
+
+	if ctx := events["onSave"]; ctx == nil || ctx.Type != "onclick" || ctx.TargetID != "saveBtn" {
+		t.Errorf("Unexpected onSave context: %+v", ctx)
+	}
+	if ctx := events["onName"]; ctx == nil || ctx.String() != "Ada" {
+		t.Errorf("Unexpected onName context: %+v", ctx)
+	}
+	if ctx := events["onAgree"]; ctx == nil || !ctx.Bool() || ctx.Value != "true" {
+		t.Errorf("Unexpected onAgree context: %+v", ctx)
+	}
+	if ctx := events["onVolume"]; ctx == nil || ctx.Float() != 7.5 || ctx.Int() != 7 {
+		t.Errorf("Unexpected onVolume context: %+v", ctx)
+	}
+	if ctx := events["onColor"]; ctx == nil || ctx.String() != "Blue" {
+		t.Errorf("Unexpected onColor context: %+v", ctx)
+	}
+	if ctx := events["onSize"]; ctx == nil || ctx.String() != "M" {
+		t.Errorf("Unexpected onSize context: %+v", ctx)
+	}
+
+	ctx := events["onSave"]
+	if ctx.Builder != builder {
+		t.Error("Expected EventContext.Builder to be the builder")
+	}
+	if ctx.Element == nil || ctx.Element.XMLName.Local != "Button" {
+		t.Errorf("Expected source element Button, got %+v", ctx.Element)
+	}
+}
+
+func TestEventHandlerFallback(t *testing.T) {
+	builder := buildEventsLayout(t)
+	handler := &testEventHandler{}
+	builder.SetEventHandler(handler)
+
+	test.Tap(builder.GetWidget("saveBtn").(*widget.Button))
+	builder.GetWidget("nameInput").(*widget.Entry).SetText("Bob")
+
+	if !handler.buttonTapped {
+		t.Error("Expected unhandled onclick to fall back to EventHandler")
+	}
+	if !handler.entryChanged || handler.lastEntryID != "nameInput" || handler.lastValue != "Bob" {
+		t.Errorf("Expected unhandled onchange to fall back to EventHandler, got %+v", handler)
+	}
+
+	// A registered callback takes precedence over the EventHandler
+	handler.buttonTapped = false
+	called := false
+	builder.On("onSave", func(ctx *EventContext) { called = true })
+	test.Tap(builder.GetWidget("saveBtn").(*widget.Button))
+	if !called || handler.buttonTapped {
+		t.Error("Expected registered callback to handle onclick")
+	}
+}
+
+func TestEventContextTypedValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		str   string
+		b     bool
+		f     float64
+	}{
+		{"bool", true, "true", true, 1},
+		{"float", 2.5, "2.5", true, 2.5},
+		{"numeric string", "3", "3", false, 3},
+		{"bool string", "true", "true", true, 0},
+		{"nil", nil, "", false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &EventContext{Value: formatEventValue(tt.value), raw: tt.value}
+			if ctx.String() != tt.str {
+				t.Errorf("String() = %q, want %q", ctx.String(), tt.str)
+			}
+			if ctx.Bool() != tt.b {
+				t.Errorf("Bool() = %v, want %v", ctx.Bool(), tt.b)
+			}
+			if ctx.Float() != tt.f {
+				t.Errorf("Float() = %v, want %v", ctx.Float(), tt.f)
+			}
+		})
+	}
+}
+
+const interactionLayoutXML = `
+<Layout>
+	<VBox>
+		<Entry id="search" onsubmit="onSearch" onfocus="onEvent" onblur="onEvent" onkey="onEvent" />
+		<Button id="menuBtn" onsecondarytap="onEvent" ondoubletap="onEvent" onhover="onEvent" onleave="onEvent">Menu</Button>
+		<Label id="info" onhover="onEvent" onleave="onEvent" ondoubletap="onEvent" onkey="onEvent">Info</Label>
+	</VBox>
+</Layout>
+`
+
+func TestInteractionEvents(t *testing.T) {
+	_ = test.NewApp()
+
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(interactionLayoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	content, err := builder.Build(layout)
+	if err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+	w := test.NewWindow(content)
+	defer w.Close()
+
+	var fired []string
+	builder.On("onEvent", func(ctx *EventContext) {
+		fired = append(fired, ctx.TargetID+":"+ctx.Type+":"+ctx.Value)
+	})
+	submitted := ""
+	builder.On("onSearch", func(ctx *EventContext) {
+		submitted = ctx.String()
+	})
+
+	// GetWidget keeps returning the standard Fyne widgets
+	entry, ok := builder.GetWidget("search").(*widget.Entry)
+	if !ok {
+		t.Fatalf("Expected search to be a *widget.Entry, got %T", builder.GetWidget("search"))
+	}
+	if _, ok := builder.GetWidget("menuBtn").(*widget.Button); !ok {
+		t.Fatalf("Expected menuBtn to be a *widget.Button, got %T", builder.GetWidget("menuBtn"))
+	}
+
+	search := builder.GetElement("search")
+	w.Canvas().Focus(search.(fyne.Focusable))
+	test.Type(entry, "go")
+	w.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	w.Canvas().Unfocus()
+
+	if submitted != "go" {
+		t.Errorf("Expected onsubmit with value go, got %q", submitted)
+	}
+
+	menu := builder.GetElement("menuBtn")
+	test.TapSecondary(menu.(fyne.SecondaryTappable))
+	test.DoubleTap(menu.(fyne.DoubleTappable))
+	menu.(desktop.Hoverable).MouseIn(&desktop.MouseEvent{})
+	menu.(desktop.Hoverable).MouseOut()
+
+	info := builder.GetElement("info")
+	info.(desktop.Hoverable).MouseIn(&desktop.MouseEvent{})
+	info.(fyne.DoubleTappable).DoubleTapped(&fyne.PointEvent{})
+	info.(fyne.Focusable).TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape})
+
+	expected := []string{
+		"search:onfocus:",
+		"search:onkey:Return",
+		"search:onblur:",
+		"menuBtn:onsecondarytap:",
+		"menuBtn:ondoubletap:",
+		"menuBtn:onhover:",
+		"menuBtn:onleave:",
+		"info:onhover:",
+		"info:ondoubletap:",
+		"info:onkey:Escape",
+	}
+	if strings.Join(fired, ",") != strings.Join(expected, ",") {
+		t.Errorf("Unexpected events:\n got %v\nwant %v", fired, expected)
+	}
+}
+
+func TestParseHandlerExpr(t *testing.T) {
+	tests := []struct {
+		attr    string
+		name    string
+		args    []argExpr
+		wantErr bool
+	}{
+		{"onSave", "onSave", nil, false},
+		{"deleteRow()", "deleteRow", nil, false},
+		{"deleteRow(42, 'draft')", "deleteRow", []argExpr{{literal: 42}, {literal: "draft"}}, false},
+		{`say("a, b", 'it\'s', 1.5, true, null)`, "say", []argExpr{{literal: "a, b"}, {literal: "it's"}, {literal: 1.5}, {literal: true}, {}}, false},
+		{"select(item.id)", "select", []argExpr{{path: "item.id"}}, false},
+		{"broken(1", "broken", nil, true},
+		{"broken('open)", "broken", nil, true},
+		{"broken(1,)", "broken", nil, true},
+		{"broken(a-b)", "broken", nil, true},
+		{"(1)", "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.attr, func(t *testing.T) {
+			expr, err := parseHandlerExpr(tt.attr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseHandlerExpr(%q) error = %v, wantErr %v", tt.attr, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if expr.name != tt.name {
+				t.Errorf("name = %q, want %q", expr.name, tt.name)
+			}
+			if len(expr.args) != len(tt.args) {
+				t.Fatalf("args = %v, want %v", expr.args, tt.args)
+			}
+			for i := range tt.args {
+				if expr.args[i] != tt.args[i] {
+					t.Errorf("arg %d = %v, want %v", i, expr.args[i], tt.args[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEventArgs(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<Button id="del" onclick="deleteRow(42, 'draft', status, item.ID, item.Tags.main, missing)">Delete</Button>
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	builder.GetBindingContext().BindString("status", "open")
+	builder.SetTemplateVariable("item", struct {
+		ID   int
+		Tags map[string]string
+	}{ID: 7, Tags: map[string]string{"main": "news"}})
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	var got *EventContext
+	builder.On("deleteRow", func(ctx *EventContext) { got = ctx })
+
+	// Binding values are read when the event fires
+	builder.GetBindingContext().BindString("status", "closed")
+	test.Tap(builder.GetWidget("del").(*widget.Button))
+
+	if got == nil {
+		t.Fatal("Expected deleteRow to be called")
+	}
+	if got.EventName != "deleteRow" {
+		t.Errorf("EventName = %q, want deleteRow", got.EventName)
+	}
+	want := []any{42, "draft", "closed", 7, "news", nil}
+	if len(got.Args) != len(want) {
+		t.Fatalf("Args = %v, want %v", got.Args, want)
+	}
+	for i := range want {
+		if got.Arg(i) != want[i] {
+			t.Errorf("Arg(%d) = %v, want %v", i, got.Arg(i), want[i])
+		}
+	}
+	if got.Arg(len(want)) != nil {
+		t.Error("Expected nil for out of range argument")
+	}
+}
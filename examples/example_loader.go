@@ -38,32 +38,25 @@ func (h *ExampleHandler) OnButtonTapped(id string) {
 		h.clearEntry("usernameField")
 		h.clearEntry("passwordField")
 
-	case "saveBtn":
-		// Form di registrazione
-		firstName := h.getEntryText("firstName")
-		lastName := h.getEntryText("lastName")
-		email := h.getEntryText("email")
-
-		msg := fmt.Sprintf("Dati salvati:\nNome: %s\nCognome: %s\nEmail: %s",
-			firstName, lastName, email)
-		h.showDialog("Salvataggio", msg)
-
-	case "resetBtn":
-		// Reset form
-		h.clearEntry("firstName")
-		h.clearEntry("lastName")
-		h.clearEntry("email")
-		h.clearEntry("phone")
-		h.clearEntry("street")
-		h.clearEntry("city")
-		h.clearEntry("zip")
-		h.clearEntry("notes")
-
 	case "menuHome", "menuStats", "menuSettings":
 		h.showDialog("Menu", fmt.Sprintf("Navigazione a: %s", id))
 	}
 }
 
+// saveForm mostra i dati inviati dal modulo di registrazione
+func (h *ExampleHandler) saveForm(ctx *flay.EventContext) {
+	msg := fmt.Sprintf("Dati salvati:\nNome: %s\nCognome: %s\nEmail: %s",
+		ctx.Values["firstName"], ctx.Values["lastName"], ctx.Values["email"])
+	h.showDialog("Salvataggio", msg)
+}
+
+// resetForm svuota il modulo di registrazione
+func (h *ExampleHandler) resetForm(*flay.EventContext) {
+	for _, id := range []string{"firstName", "lastName", "email", "phone", "street", "city", "zip", "notes"} {
+		h.clearEntry(id)
+	}
+}
+
 func (h *ExampleHandler) OnEntryChanged(id, value string) {
 	log.Printf("Campo '%s' modificato: %s", id, value)
 }
@@ -118,6 +111,8 @@ func main() {
 	}
 
 	handler.builder = builder
+	builder.On("saveForm", handler.saveForm)
+	builder.On("resetForm", handler.resetForm)
 
 	myWindow.SetContent(content)
 	myWindow.ShowAndRun()
@@ -0,0 +1,497 @@
+package fylay
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// expr is a parsed binding expression, e.g. "first + ' ' + last" or
+// "count > 0 && !done". Identifiers are binding keys and can be nested
+// paths like user.Name. Supported operators, by increasing precedence:
+// ?:, ||, &&, == !=, < <= > >=, + -, * / %, unary ! and -.
+type expr struct {
+	src  string
+	root exprNode
+	keys []string // Binding keys used, in order of appearance
+}
+
+// exprNode is a node of a parsed expression
+type exprNode interface {
+	eval(vars map[string]any) (any, error)
+}
+
+// parseExpr parses an expression
+func parseExpr(src string) (*expr, error) {
+	tokens, err := tokenizeExpr(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in expression %q", p.tokens[p.pos].text, src)
+	}
+
+	return &expr{src: src, root: root, keys: p.keys}, nil
+}
+
+// eval evaluates the expression with the values of its binding keys
+func (e *expr) eval(vars map[string]any) (any, error) {
+	return e.root.eval(vars)
+}
+
+// exprToken is a token of an expression
+type exprToken struct {
+	kind  byte // 'n' number, 's' string, 'i' identifier, 'o' operator
+	text  string
+	value any
+}
+
+// exprOperators are the operators, longest first
+var exprOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "+", "-", "*", "/", "%", "<", ">", "!", "?", ":", "(", ")"}
+
+// tokenizeExpr splits an expression into tokens
+func tokenizeExpr(src string) ([]exprToken, error) {
+	var tokens []exprToken
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '\'' || c == '"':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(src) && src[j] != c; j++ {
+				if src[j] == '\\' && j+1 < len(src) {
+					j++
+				}
+				sb.WriteByte(src[j])
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("unterminated string in expression %q", src)
+			}
+			tokens = append(tokens, exprToken{kind: 's', text: src[i : j+1], value: sb.String()})
+			i = j + 1
+
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(src) && (src[j] >= '0' && src[j] <= '9' || src[j] == '.') {
+				j++
+			}
+			text := src[i:j]
+			var value any
+			if n, err := strconv.Atoi(text); err == nil {
+				value = n
+			} else if f, err := strconv.ParseFloat(text, 64); err == nil {
+				value = f
+			} else {
+				return nil, fmt.Errorf("invalid number %q in expression %q", text, src)
+			}
+			tokens = append(tokens, exprToken{kind: 'n', text: text, value: value})
+			i = j
+
+		case c == '_' || unicode.IsLetter(rune(c)):
+			j := i
+			for j < len(src) && (src[j] == '_' || src[j] == '.' || unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j]))) {
+				j++
+			}
+			tokens = append(tokens, exprToken{kind: 'i', text: src[i:j]})
+			i = j
+
+		default:
+			op := ""
+			for _, candidate := range exprOperators {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q in expression %q", c, src)
+			}
+			tokens = append(tokens, exprToken{kind: 'o', text: op})
+			i += len(op)
+		}
+	}
+
+	return tokens, nil
+}
+
+// exprParser is a recursive descent parser of expressions
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+	keys   []string
+}
+
+// accept consumes the next token if it is one of the operators
+func (p *exprParser) accept(ops ...string) (string, bool) {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != 'o' {
+		return "", false
+	}
+	for _, op := range ops {
+		if p.tokens[p.pos].text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// parseTernary parses cond ? a : b
+func (p *exprParser) parseTernary() (exprNode, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("?"); !ok {
+		return cond, nil
+	}
+
+	then, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept(":"); !ok {
+		return nil, errors.New("expected ':' in conditional expression")
+	}
+	otherwise, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+
+	return ternaryNode{cond: cond, then: then, otherwise: otherwise}, nil
+}
+
+// exprPrecedence lists the binary operators by increasing precedence
+var exprPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// parseBinary parses the binary operators of a precedence level and above
+func (p *exprParser) parseBinary(level int) (exprNode, error) {
+	if level == len(exprPrecedence) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(exprPrecedence[level]...)
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+// parseUnary parses ! and unary -
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if op, ok := p.accept("!", "-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryNode{op: op, operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses literals, binding keys and parentheses
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, errors.New("unexpected end of expression")
+	}
+
+	if _, ok := p.accept("("); ok {
+		node, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.accept(")"); !ok {
+			return nil, errors.New("expected ')' in expression")
+		}
+		return node, nil
+	}
+
+	tok := p.tokens[p.pos]
+	p.pos++
+	switch tok.kind {
+	case 'n', 's':
+		return literalNode{value: tok.value}, nil
+	case 'i':
+		switch tok.text {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		case "null", "nil":
+			return literalNode{value: nil}, nil
+		}
+		p.addKey(tok.text)
+		return keyNode{key: tok.text}, nil
+	default:
+		return nil, fmt.Errorf("unexpected %q in expression", tok.text)
+	}
+}
+
+// addKey records a binding key used by the expression
+func (p *exprParser) addKey(key string) {
+	for _, k := range p.keys {
+		if k == key {
+			return
+		}
+	}
+	p.keys = append(p.keys, key)
+}
+
+// literalNode is a constant
+type literalNode struct {
+	value any
+}
+
+func (n literalNode) eval(map[string]any) (any, error) {
+	return n.value, nil
+}
+
+// keyNode is the value of a binding key
+type keyNode struct {
+	key string
+}
+
+func (n keyNode) eval(vars map[string]any) (any, error) {
+	return vars[n.key], nil
+}
+
+// ternaryNode is cond ? then : otherwise
+type ternaryNode struct {
+	cond, then, otherwise exprNode
+}
+
+func (n ternaryNode) eval(vars map[string]any) (any, error) {
+	cond, err := n.cond.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	if truthy(cond) {
+		return n.then.eval(vars)
+	}
+	return n.otherwise.eval(vars)
+}
+
+// unaryNode is !operand or -operand
+type unaryNode struct {
+	op      string
+	operand exprNode
+}
+
+func (n unaryNode) eval(vars map[string]any) (any, error) {
+	v, err := n.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "!" {
+		return !truthy(v), nil
+	}
+	if i, ok := v.(int); ok {
+		return -i, nil
+	}
+	f, err := toFloat(v)
+	if err != nil {
+		return nil, err
+	}
+	return -f, nil
+}
+
+// binaryNode is left op right
+type binaryNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n binaryNode) eval(vars map[string]any) (any, error) {
+	left, err := n.left.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	// Logical operators short-circuit
+	switch n.op {
+	case "&&":
+		if !truthy(left) {
+			return false, nil
+		}
+		right, err := n.right.eval(vars)
+		return truthy(right), err
+	case "||":
+		if truthy(left) {
+			return true, nil
+		}
+		right, err := n.right.eval(vars)
+		return truthy(right), err
+	}
+
+	right, err := n.right.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return equalValues(left, right), nil
+	case "!=":
+		return !equalValues(left, right), nil
+	case "<", "<=", ">", ">=":
+		return compareValues(n.op, left, right), nil
+	case "+":
+		_, ls := left.(string)
+		_, rs := right.(string)
+		if ls || rs {
+			return formatValue(left) + formatValue(right), nil
+		}
+	}
+
+	return arithmetic(n.op, left, right)
+}
+
+// arithmetic applies an arithmetic operator. Ints stay ints, except for
+// division.
+func arithmetic(op string, left, right any) (any, error) {
+	li, lok := left.(int)
+	ri, rok := right.(int)
+	if lok && rok && op != "/" {
+		switch op {
+		case "+":
+			return li + ri, nil
+		case "-":
+			return li - ri, nil
+		case "*":
+			return li * ri, nil
+		case "%":
+			if ri == 0 {
+				return nil, errors.New("modulo by zero")
+			}
+			return li % ri, nil
+		}
+	}
+
+	lf, err := toFloat(left)
+	if err != nil {
+		return nil, fmt.Errorf("operator %s: %w", op, err)
+	}
+	rf, err := toFloat(right)
+	if err != nil {
+		return nil, fmt.Errorf("operator %s: %w", op, err)
+	}
+
+	switch op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	case "/":
+		if rf == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return lf / rf, nil
+	default:
+		return math.Mod(lf, rf), nil
+	}
+}
+
+// isNumber reports whether v is a number
+func isNumber(v any) bool {
+	switch v.(type) {
+	case int, int32, int64, float32, float64:
+		return true
+	}
+	return false
+}
+
+// equalValues compares numbers by value, booleans as booleans and anything
+// else by its text
+func equalValues(left, right any) bool {
+	if isNumber(left) && isNumber(right) {
+		lf, _ := toFloat(left)  //nolint:errcheck // Numbers always convert
+		rf, _ := toFloat(right) //nolint:errcheck // Numbers always convert
+		return lf == rf
+	}
+	lb, lok := left.(bool)
+	rb, rok := right.(bool)
+	if lok && rok {
+		return lb == rb
+	}
+	return formatValue(left) == formatValue(right)
+}
+
+// compareValues orders numbers by value and anything else by its text
+func compareValues(op string, left, right any) bool {
+	var c int
+	if isNumber(left) && isNumber(right) {
+		lf, _ := toFloat(left)  //nolint:errcheck // Numbers always convert
+		rf, _ := toFloat(right) //nolint:errcheck // Numbers always convert
+		switch {
+		case lf < rf:
+			c = -1
+		case lf > rf:
+			c = 1
+		}
+	} else {
+		c = strings.Compare(formatValue(left), formatValue(right))
+	}
+
+	switch op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+// truthy reports whether a value counts as true: false, zero, empty strings
+// and lists and nil are false
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []string:
+		return len(v) > 0
+	default:
+		if isNumber(v) {
+			f, _ := toFloat(v) //nolint:errcheck // Numbers always convert
+			return f != 0
+		}
+		return true
+	}
+}
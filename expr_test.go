@@ -0,0 +1,85 @@
+package fylay
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExprEval(t *testing.T) {
+	vars := map[string]any{
+		"first":     "Ada",
+		"last":      "Lovelace",
+		"empty":     "",
+		"count":     3,
+		"price":     2.5,
+		"ok":        true,
+		"user.Name": "Grace",
+	}
+
+	tests := []struct {
+		src  string
+		want any
+	}{
+		{"first + ' ' + last", "Ada Lovelace"},
+		{`"Hi " + user.Name`, "Hi Grace"},
+		{"count * 2 + 1", 7},
+		{"count * price", 7.5},
+		{"count / 2", 1.5},
+		{"count % 2", 1},
+		{"-count", -3},
+		{"(count + 1) * 2", 8},
+		{"first != '' && last != ''", true},
+		{"first != '' && empty != ''", false},
+		{"empty || first", true},
+		{"!ok", false},
+		{"count >= 3", true},
+		{"price < 2", false},
+		{"count == 3.0", true},
+		{"ok == true", true},
+		{"count > 1 ? 'many' : 'one'", "many"},
+		{"missing", nil},
+		{"'a' + count", "a3"},
+	}
+
+	for _, tt := range tests {
+		e, err := parseExpr(tt.src)
+		if err != nil {
+			t.Errorf("parseExpr(%q) failed: %v", tt.src, err)
+			continue
+		}
+		got, err := e.eval(vars)
+		if err != nil {
+			t.Errorf("eval(%q) failed: %v", tt.src, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("eval(%q) = %#v, want %#v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestExprKeys(t *testing.T) {
+	e, err := parseExpr("first + ' ' + last + (first == '' ? user.Name : '')")
+	if err != nil {
+		t.Fatalf("parseExpr failed: %v", err)
+	}
+	if want := []string{"first", "last", "user.Name"}; !slices.Equal(e.keys, want) {
+		t.Errorf("keys = %v, want %v", e.keys, want)
+	}
+}
+
+func TestExprErrors(t *testing.T) {
+	for _, src := range []string{"", "first +", "'open", "(count", "count ? 1", "a # b", "count count"} {
+		if _, err := parseExpr(src); err == nil {
+			t.Errorf("parseExpr(%q) should fail", src)
+		}
+	}
+
+	e, err := parseExpr("count / 0")
+	if err != nil {
+		t.Fatalf("parseExpr failed: %v", err)
+	}
+	if _, err := e.eval(map[string]any{"count": 1}); err == nil {
+		t.Error("division by zero should fail")
+	}
+}
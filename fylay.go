@@ -1,15 +1,14 @@
 package fylay
 
 import (
-	"encoding/xml"
-	"fmt"
-	"io"
 	"strconv"
 	"strings"
+	"sync"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/sandrolain/fylay/core"
@@ -24,212 +23,197 @@ const (
 	attrValueTrue = core.AttrValueTrue
 )
 
-// Layout rappresenta un layout XML parsato
-type Layout struct {
-	XMLName xml.Name `xml:"Layout"`
-	Styles  []Style  `xml:"Style"`
-	Root    Element  `xml:",any"`
-}
+// Layout rappresenta un layout parsato
+type Layout = core.Layout
 
 // Style rappresenta una regola di stile CSS
-type Style struct {
-	Selector   string            `xml:"selector,attr"`
-	Properties map[string]string `xml:"-"`
-	RawCSS     string            `xml:",innerxml"`
-}
+type Style = core.Style
 
 // Element rappresenta un elemento generico del layout
-type Element struct {
-	XMLName    xml.Name
-	ID         string     `xml:"id,attr"`
-	Class      string     `xml:"class,attr"`
-	Style      string     `xml:"style,attr"`
-	Text       string     `xml:"text,attr"`
-	Attributes []xml.Attr `xml:",any,attr"`
-	Children   []Element  `xml:",any"`
-	Content    string     `xml:",chardata"`
-}
+type Element = core.Element
 
-// EventContext contiene le informazioni di contesto di un evento
-type EventContext struct {
-	// EventName è il nome dell'evento (es. "onclick", "onchange")
-	EventName string
-	// Target è l'elemento che ha generato l'evento
-	Target fyne.CanvasObject
-	// TargetID è l'ID dell'elemento che ha generato l'evento
-	TargetID string
-	// Value è il valore associato all'evento (per Entry)
-	Value string
-}
+// ElementFactory costruisce l'oggetto Fyne per un tipo di elemento
+type ElementFactory = core.ElementFactory
 
-// ButtonCallback è una funzione callback per eventi di pulsanti
-type ButtonCallback func(ctx *EventContext)
+// LayoutFormat identifica la sintassi di un documento di layout
+type LayoutFormat = core.LayoutFormat
 
-// EntryCallback è una funzione callback per eventi Entry
-type EntryCallback func(ctx *EventContext)
+// Formati di layout supportati
+const (
+	FormatXML  = core.FormatXML
+	FormatYAML = core.FormatYAML
+	FormatJSON = core.FormatJSON
+)
 
-// Builder costruisce i widget Fyne dal layout
-type Builder struct {
-	styles          map[string]Style
-	elements        map[string]fyne.CanvasObject
-	widgets         map[string]fyne.CanvasObject // Original widgets before wrapping
-	eventHandler    EventHandler
-	eventCallbacks  map[string]ButtonCallback
-	entryCallbacks  map[string]EntryCallback
-	bindingContext  *BindingContext
-	templateContext *TemplateContext
+// DetectLayoutFormat restituisce il formato di layout in base all'estensione del file
+func DetectLayoutFormat(path string) LayoutFormat {
+	return core.DetectLayoutFormat(path)
 }
 
-// EventHandler gestisce gli eventi dei widget
-type EventHandler interface {
-	OnButtonTapped(id string)
-	OnEntryChanged(id, value string)
+// Builder costruisce i widget Fyne dal layout.
+// Il parsing, il cascade degli stili e il registro degli elementi sono
+// forniti da core.Builder; qui si aggiungono i widget predefiniti,
+// gli eventi e i contesti di binding e template.
+type Builder struct {
+	*core.Builder
+	mu                sync.RWMutex // Protects the fields below
+	eventHandler      EventHandler
+	callbacks         map[string]EventCallback
+	handlerAliases    map[string]string // Nomi minuscoli degli handler dei controller, verso il nome del metodo
+	bindingContext    *BindingContext
+	templateContext   *TemplateContext
+	limiters          map[limiterKey]*eventLimiter // Debounce e throttle in corso
+	asyncRuns         map[asyncKey]*asyncRun       // Callback asincrone in esecuzione
+	asyncErrorHandler AsyncErrorHandler
+	eventTracer       EventTracer
+	strictEvents      bool
+	validators        map[string]fyne.StringValidator // Validatori con nome
+	scope             *buildScope                     // Risorse dell'interfaccia costruita
+	nextScope         *buildScope                     // Risorse dell'interfaccia in costruzione con Rebuild
 }
 
 // NewBuilder crea un nuovo builder
 func NewBuilder() *Builder {
-	return &Builder{
-		styles:         make(map[string]Style),
-		elements:       make(map[string]fyne.CanvasObject),
-		widgets:        make(map[string]fyne.CanvasObject),
-		eventCallbacks: make(map[string]ButtonCallback),
-		entryCallbacks: make(map[string]EntryCallback),
+	b := &Builder{
+		Builder:        core.NewBuilder(),
+		callbacks:      make(map[string]EventCallback),
+		handlerAliases: make(map[string]string),
+		limiters:       make(map[limiterKey]*eventLimiter),
+		asyncRuns:      make(map[asyncKey]*asyncRun),
+		validators:     make(map[string]fyne.StringValidator),
+		scope:          &buildScope{},
+	}
+	b.registerBuiltins()
+	return b
+}
+
+// Build costruisce l'interfaccia dal layout; in modalità strict fallisce se
+// il layout usa handler non registrati. Gli eventi rimandati, le callback
+// asincrone e i segnaposto dell'interfaccia costruita in precedenza vengono
+// annullati.
+func (b *Builder) Build(layout *Layout) (fyne.CanvasObject, error) {
+	if err := b.checkStrictEvents(layout); err != nil {
+		return nil, err
 	}
-}
-
-// SetEventHandler imposta l'handler degli eventi
-func (b *Builder) SetEventHandler(handler EventHandler) {
-	b.eventHandler = handler
-}
 
-// On registra una callback per un evento specifico (per pulsanti)
-func (b *Builder) On(eventName string, callback ButtonCallback) {
-	b.eventCallbacks[eventName] = callback
+	b.releaseBuilt(&buildScope{})
+	return b.Builder.Build(layout)
 }
 
-// OnEntry registra una callback per eventi Entry
-func (b *Builder) OnEntry(eventName string, callback EntryCallback) {
-	b.entryCallbacks[eventName] = callback
-}
-
-// LoadLayout carica un layout da un reader XML
-func (b *Builder) LoadLayout(r io.Reader) (*Layout, error) {
-	var layout Layout
-	decoder := xml.NewDecoder(r)
-	if err := decoder.Decode(&layout); err != nil {
-		return nil, fmt.Errorf("errore parsing XML: %w", err)
-	}
-
-	// Parse CSS styles
-	for i := range layout.Styles {
-		layout.Styles[i].Properties = parseCSS(layout.Styles[i].RawCSS)
-		b.styles[layout.Styles[i].Selector] = layout.Styles[i]
+// Rebuild ricostruisce il layout da zero; gli eventi rimandati, le
+// callback asincrone e i segnaposto degli elementi sostituiti vengono
+// annullati. In
+// modalità strict il layout corrente resta in uso se il nuovo usa handler
+// non registrati.
+func (b *Builder) Rebuild(layout *Layout) (fyne.CanvasObject, error) {
+	if err := b.checkStrictEvents(layout); err != nil {
+		return nil, err
 	}
 
-	return &layout, nil
-}
-
-// Build costruisce l'interfaccia dal layout
-func (b *Builder) Build(layout *Layout) (fyne.CanvasObject, error) {
-	return b.buildElement(layout.Root)
-}
-
-// GetElement restituisce un elemento per ID
-func (b *Builder) GetElement(id string) fyne.CanvasObject {
-	return b.elements[id]
-}
+	// Le risorse del nuovo layout restano separate finché non lo sostituisce
+	next := &buildScope{}
+	b.mu.Lock()
+	b.nextScope = next
+	b.mu.Unlock()
 
-// GetWidget restituisce il widget originale (non wrappato) per ID
-func (b *Builder) GetWidget(id string) fyne.CanvasObject {
-	if widget, ok := b.widgets[id]; ok {
-		return widget
+	obj, err := b.Builder.Rebuild(layout)
+	if err != nil {
+		b.mu.Lock()
+		b.nextScope = nil
+		b.mu.Unlock()
+		next.release()
+		return obj, err
+	}
+	b.releaseBuilt(next)
+	return obj, nil
+}
+
+// Reset rimuove stili ed elementi costruiti e annulla gli eventi rimandati,
+// le callback asincrone e i segnaposto
+func (b *Builder) Reset() {
+	b.Builder.Reset()
+	b.releaseBuilt(&buildScope{})
+}
+
+// releaseBuilt rilascia quanto legato all'interfaccia sostituita da Build,
+// Rebuild o Reset: lo scope, che passa a next, gli eventi rimandati e le
+// callback asincrone in esecuzione
+func (b *Builder) releaseBuilt(next *buildScope) {
+	b.replaceScope(next)
+	b.cancelPendingEvents()
+	b.CancelAsync()
+}
+
+// registerBuiltins registra le factory dei widget predefiniti sul builder
+func (b *Builder) registerBuiltins() {
+	builtins := map[string]func(Element, map[string]string) fyne.CanvasObject{
+		"VBox":        b.buildVBox,
+		"HBox":        b.buildHBox,
+		"Grid":        b.buildGrid,
+		"Border":      b.buildBorder,
+		"Label":       b.buildLabel,
+		"Button":      b.buildButton,
+		"Entry":       b.buildEntry,
+		"Rectangle":   b.buildRectangle,
+		"Circle":      b.buildCircle,
+		"Text":        b.buildText,
+		"Spacer":      b.buildSpacer,
+		"Checkbox":    b.buildCheckbox,
+		"Select":      b.buildSelect,
+		"ProgressBar": b.buildProgressBar,
+		"Slider":      b.buildSlider,
+		"Image":       b.buildImage,
+		"RadioGroup":  b.buildRadioGroup,
+		"CheckGroup":  b.buildCheckGroup,
+		"List":        b.buildList,
+		"Table":       b.buildTable,
+		"Tree":        b.buildTree,
+		"Form":        b.buildForm,
+	}
+
+	// Widget che gestiscono direttamente gli eventi di interazione
+	interactive := map[string]bool{
+		"Button":   true,
+		"Entry":    true,
+		"Checkbox": true,
+		"Select":   true,
+		"Slider":   true,
+	}
+
+	for name, build := range builtins {
+		if interactive[name] {
+			b.RegisterFactory(name, func(_ *core.Builder, elem Element, style map[string]string) (fyne.CanvasObject, error) {
+				return build(elem, style), nil
+			})
+			continue
+		}
+		b.RegisterFactory(name, func(_ *core.Builder, elem Element, style map[string]string) (fyne.CanvasObject, error) {
+			return b.wrapInteractionEvents(&elem, build(elem, style)), nil
+		})
 	}
-	return b.elements[id] // Fallback to element if no widget
-}
 
-// buildElement costruisce ricorsivamente un elemento
-func (b *Builder) buildElement(elem Element) (fyne.CanvasObject, error) {
-	// Calcola lo stile finale (combinando class, id e inline style)
-	style := b.computeStyle(elem)
-
-	var obj fyne.CanvasObject
-	var err error
-
-	switch elem.XMLName.Local {
-	case "VBox":
-		obj = b.buildVBox(elem, style)
-	case "HBox":
-		obj = b.buildHBox(elem, style)
-	case "Grid":
-		obj = b.buildGrid(elem, style)
-	case "Border":
-		obj = b.buildBorder(elem, style)
-	case "Label":
-		obj = b.buildLabel(elem, style)
-	case "Button":
-		obj = b.buildButton(elem, style)
-	case "Entry":
-		obj = b.buildEntry(elem, style)
-	case "Rectangle":
-		obj = b.buildRectangle(elem, style)
-	case "Circle":
-		obj = b.buildCircle(elem, style)
-	case "Text":
-		obj = b.buildText(elem, style)
-	case "Spacer":
-		obj = widget.NewLabel("")
-	case "Checkbox":
-		obj = b.buildCheckbox(elem, style)
-	case "Select":
-		obj = b.buildSelect(elem, style)
-	case "ProgressBar":
-		obj = b.buildProgressBar(elem, style)
-	case "Slider":
-		obj = b.buildSlider(elem, style)
-	case "Image":
-		obj = b.buildImage(elem, style)
-	case "RadioGroup":
-		obj = b.buildRadioGroup(elem, style)
-	default:
-		return nil, fmt.Errorf("tipo di elemento sconosciuto: %s", elem.XMLName.Local)
-	}
-
-	return obj, err
+	// Elementi senza rappresentazione grafica
+	b.RegisterFactory("Computed", func(_ *core.Builder, elem Element, _ map[string]string) (fyne.CanvasObject, error) {
+		return b.buildComputed(elem)
+	})
 }
 
 // buildVBox costruisce un container verticale
 func (b *Builder) buildVBox(elem Element, style map[string]string) fyne.CanvasObject {
-	children := make([]fyne.CanvasObject, 0, len(elem.Children))
-	for _, child := range elem.Children {
-		if obj, err := b.buildElement(child); err == nil && obj != nil {
-			children = append(children, obj)
-		}
-	}
-	return container.NewVBox(children...)
+	return container.NewVBox(b.BuildChildren(elem)...)
 }
 
 // buildHBox costruisce un container orizzontale
 func (b *Builder) buildHBox(elem Element, style map[string]string) fyne.CanvasObject {
-	children := make([]fyne.CanvasObject, 0, len(elem.Children))
-	for _, child := range elem.Children {
-		if obj, err := b.buildElement(child); err == nil && obj != nil {
-			children = append(children, obj)
-		}
-	}
-	return container.NewHBox(children...)
+	return container.NewHBox(b.BuildChildren(elem)...)
 }
 
 // buildGrid costruisce un layout a griglia
 func (b *Builder) buildGrid(elem Element, style map[string]string) fyne.CanvasObject {
-	children := make([]fyne.CanvasObject, 0, len(elem.Children))
-	for _, child := range elem.Children {
-		if obj, err := b.buildElement(child); err == nil && obj != nil {
-			children = append(children, obj)
-		}
-	}
+	children := b.BuildChildren(elem)
 
 	cols := 2
-	if colsStr := elem.getAttr("columns"); colsStr != "" {
+	if colsStr := elem.GetAttr("columns"); colsStr != "" {
 		if c, err := strconv.Atoi(colsStr); err == nil {
 			cols = c
 		}
@@ -243,12 +227,12 @@ func (b *Builder) buildBorder(elem Element, style map[string]string) fyne.Canvas
 	var top, bottom, left, right, center fyne.CanvasObject
 
 	for _, child := range elem.Children {
-		obj, err := b.buildElement(child)
+		obj, err := b.BuildElement(child)
 		if err != nil || obj == nil {
 			continue
 		}
 
-		pos := child.getAttr("position")
+		pos := child.GetAttr("position")
 		switch pos {
 		case "top":
 			top = obj
@@ -266,6 +250,11 @@ func (b *Builder) buildBorder(elem Element, style map[string]string) fyne.Canvas
 	return container.NewBorder(top, bottom, left, right, center)
 }
 
+// buildSpacer costruisce uno spazio vuoto
+func (b *Builder) buildSpacer(elem Element, style map[string]string) fyne.CanvasObject {
+	return widget.NewLabel("")
+}
+
 // buildLabel costruisce una label
 func (b *Builder) buildLabel(elem Element, style map[string]string) fyne.CanvasObject {
 	text := elem.Text
@@ -294,18 +283,29 @@ func (b *Builder) buildLabel(elem Element, style map[string]string) fyne.CanvasO
 		label.TextStyle.Italic = true
 	}
 
+	// Handle data binding (one-way, the text is the initial value)
+	if bindAttr := elem.GetAttr("bind"); bindAttr != "" {
+		if data := b.GetBindingContext().stringBinding(bindAttr, text); data != nil {
+			label.Bind(data)
+		}
+	} else if hasInterpolation(text) {
+		// Placeholders like ${count} follow the bindings they use
+		if data := b.interpolate(text); data != nil {
+			label.Bind(data)
+		}
+	}
+
 	// Store widget with ID before applying styles
 	if elem.ID != "" {
-		b.widgets[elem.ID] = label // Original widget
+		b.GetBindingContext().RegisterWidget(elem.ID, label)
 	}
+	b.RegisterWidget(elem.ID, label)
 
 	// Label doesn't typically need size styling, but support it for consistency
-	styled := applyMinSize(label, style)
+	styled := core.ApplyMinSize(label, style)
 
 	// Store the final styled version
-	if elem.ID != "" {
-		b.elements[elem.ID] = styled
-	}
+	b.RegisterElement(elem.ID, styled)
 
 	return styled
 }
@@ -317,98 +317,99 @@ func (b *Builder) buildButton(elem Element, style map[string]string) fyne.Canvas
 		text = strings.TrimSpace(elem.Content)
 	}
 
-	// Verifica se c'è un attributo onclick
-	onclick := elem.getAttr("onclick")
-
-	var btn *widget.Button
-	btn = widget.NewButton(text, func() {
+	obj, btn := b.newButton(&elem)
+	btn.Text = text
+	btn.OnTapped = func() {
 		// Prima prova a chiamare la callback registrata
-		if onclick != "" {
-			if callback, ok := b.eventCallbacks[onclick]; ok {
-				ctx := &EventContext{
-					EventName: onclick,
-					Target:    btn,
-					TargetID:  elem.ID,
-				}
-				callback(ctx)
-				return
-			}
+		if b.fireEvent(eventClick, &elem, btn, nil) {
+			return
 		}
 
 		// Altrimenti usa l'EventHandler tradizionale
-		if b.eventHandler != nil && elem.ID != "" {
-			b.eventHandler.OnButtonTapped(elem.ID)
+		if handler := b.getEventHandler(); handler != nil && elem.ID != "" {
+			handler.OnButtonTapped(elem.ID)
 		}
-	})
+	}
 
 	// Store widget with ID before applying styles
-	if elem.ID != "" {
-		b.widgets[elem.ID] = btn // Original widget
-	}
+	b.RegisterWidget(elem.ID, btn)
 
 	// Apply common styles (width, height) - may wrap in container
-	styled := applyMinSize(btn, style)
+	styled := core.ApplyMinSize(obj, style)
 
 	// Store the final styled version
-	if elem.ID != "" {
-		b.elements[elem.ID] = styled
-	}
+	b.RegisterElement(elem.ID, styled)
 
 	return styled
 }
 
 // buildEntry costruisce un campo di input
 func (b *Builder) buildEntry(elem Element, style map[string]string) fyne.CanvasObject {
-	entry := widget.NewEntry()
+	obj, entry := b.newEntry(&elem)
 
-	if placeholder := elem.getAttr("placeholder"); placeholder != "" {
+	if placeholder := elem.GetAttr("placeholder"); placeholder != "" {
 		entry.PlaceHolder = placeholder
 	}
 
-	if elem.getAttr("password") == "true" {
+	if elem.GetAttr("password") == "true" {
 		entry.Password = true
 	}
 
-	if elem.getAttr("multiline") == "true" {
+	if elem.GetAttr("multiline") == "true" {
 		entry.MultiLine = true
 	}
 
-	// Verifica se c'è un attributo onchange
-	onchange := elem.getAttr("onchange")
-
+	var field *fieldValidation
 	entry.OnChanged = func(value string) {
+		field.changed()
+
 		// Prima prova a chiamare la callback registrata
-		if onchange != "" {
-			if callback, ok := b.entryCallbacks[onchange]; ok {
-				ctx := &EventContext{
-					EventName: onchange,
-					Target:    entry,
-					TargetID:  elem.ID,
-					Value:     value,
-				}
-				callback(ctx)
-				return
-			}
+		if b.fireEvent(eventChange, &elem, entry, value) {
+			return
 		}
 
 		// Altrimenti usa l'EventHandler tradizionale
-		if b.eventHandler != nil && elem.ID != "" {
-			b.eventHandler.OnEntryChanged(elem.ID, value)
+		if handler := b.getEventHandler(); handler != nil && elem.ID != "" {
+			handler.OnEntryChanged(elem.ID, value)
+		}
+	}
+
+	// Handle data binding (two-way)
+	if bindAttr := elem.GetAttr("bind"); bindAttr != "" {
+		if data := b.GetBindingContext().stringBinding(bindAttr, ""); data != nil {
+			entry.Bind(data)
+		}
+	}
+
+	// Regole di validazione, dopo il binding che imposta il suo validatore
+	field = b.addField(&elem, entry.Validator,
+		func() string { return entry.Text },
+		func(err error) {
+			entry.AlwaysShowValidationError = true
+			entry.SetValidationError(err)
+		})
+	if field != nil {
+		entry.Validator = field.validator
+	}
+
+	// Gestisce l'invio (tasto Enter)
+	if elem.GetAttr(eventSubmit) != "" {
+		entry.OnSubmitted = func(value string) {
+			b.fireEvent(eventSubmit, &elem, entry, value)
 		}
 	}
 
 	// Store widget with ID before applying styles
 	if elem.ID != "" {
-		b.widgets[elem.ID] = entry // Original widget
+		b.GetBindingContext().RegisterWidget(elem.ID, entry)
 	}
+	b.RegisterWidget(elem.ID, entry)
 
 	// Apply common styles (width, height) - may wrap in container
-	styled := applyMinSize(entry, style)
+	styled := core.ApplyMinSize(obj, style)
 
 	// Store the final styled version
-	if elem.ID != "" {
-		b.elements[elem.ID] = styled
-	}
+	b.RegisterElement(elem.ID, styled)
 
 	return styled
 }
@@ -418,21 +419,19 @@ func (b *Builder) buildRectangle(elem Element, style map[string]string) fyne.Can
 	rect := canvas.NewRectangle(parseColor(style["background-color"]))
 
 	if w := style["width"]; w != "" {
-		if width, err := parseSize(w); err == nil {
+		if width, err := core.ParseSize(w); err == nil {
 			rect.SetMinSize(fyne.NewSize(width, rect.MinSize().Height))
 		}
 	}
 
 	if h := style["height"]; h != "" {
-		if height, err := parseSize(h); err == nil {
+		if height, err := core.ParseSize(h); err == nil {
 			rect.SetMinSize(fyne.NewSize(rect.MinSize().Width, height))
 		}
 	}
 
 	// Store canvas object with ID
-	if elem.ID != "" {
-		b.elements[elem.ID] = rect
-	}
+	b.RegisterElement(elem.ID, rect)
 
 	return rect
 }
@@ -442,15 +441,13 @@ func (b *Builder) buildCircle(elem Element, style map[string]string) fyne.Canvas
 	circle := canvas.NewCircle(parseColor(style["background-color"]))
 
 	if w := style["width"]; w != "" {
-		if width, err := parseSize(w); err == nil {
+		if width, err := core.ParseSize(w); err == nil {
 			circle.Resize(fyne.NewSize(width, width))
 		}
 	}
 
 	// Store canvas object with ID
-	if elem.ID != "" {
-		b.elements[elem.ID] = circle
-	}
+	b.RegisterElement(elem.ID, circle)
 
 	return circle
 }
@@ -465,7 +462,7 @@ func (b *Builder) buildText(elem Element, style map[string]string) fyne.CanvasOb
 	txt := canvas.NewText(text, parseColor(style["color"]))
 
 	if size := style["font-size"]; size != "" {
-		if s, err := parseSize(size); err == nil {
+		if s, err := core.ParseSize(size); err == nil {
 			txt.TextSize = s
 		}
 	}
@@ -481,64 +478,27 @@ func (b *Builder) buildText(elem Element, style map[string]string) fyne.CanvasOb
 		}
 	}
 
-	// Store canvas object with ID
-	if elem.ID != "" {
-		b.elements[elem.ID] = txt
+	// Handle data binding (one-way, canvas.Text has no Bind); placeholders
+	// like ${count} follow the bindings they use until the text is replaced
+	var data binding.String
+	if bindAttr := elem.GetAttr("bind"); bindAttr != "" {
+		data = b.GetBindingContext().stringBinding(bindAttr, text)
+	} else if hasInterpolation(text) {
+		data = b.interpolate(text)
 	}
-
-	return txt
-}
-
-// parseCSS analizza una stringa CSS e restituisce una mappa di proprietà
-func parseCSS(css string) map[string]string {
-	props := make(map[string]string)
-
-	// Rimuovi eventuali { }
-	css = strings.TrimSpace(css)
-	css = strings.Trim(css, "{}")
-
-	// Splitta per punto e virgola
-	declarations := strings.Split(css, ";")
-	for _, decl := range declarations {
-		decl = strings.TrimSpace(decl)
-		if decl == "" {
-			continue
-		}
-
-		parts := strings.SplitN(decl, ":", 2)
-		if len(parts) == 2 {
-			key := strings.TrimSpace(parts[0])
-			value := strings.TrimSpace(parts[1])
-			props[key] = value
-		}
-	}
-
-	return props
-}
-
-// parseSize converte una stringa dimensione in float32
-func parseSize(sizeStr string) (float32, error) {
-	sizeStr = strings.TrimSpace(sizeStr)
-
-	// Rimuovi "px" se presente
-	sizeStr = strings.TrimSuffix(sizeStr, "px")
-
-	val, err := strconv.ParseFloat(sizeStr, 32)
-	if err != nil {
-		return 0, err
+	if data != nil {
+		b.buildingScope().listen(data, binding.NewDataListener(func() {
+			if value, err := data.Get(); err == nil {
+				txt.Text = value
+				txt.Refresh()
+			}
+		}))
 	}
 
-	return float32(val), nil
-}
+	// Store canvas object with ID
+	b.RegisterElement(elem.ID, txt)
 
-// getAttr ottiene un attributo dall'elemento
-func (e *Element) getAttr(name string) string {
-	for _, attr := range e.Attributes {
-		if attr.Name.Local == name {
-			return attr.Value
-		}
-	}
-	return ""
+	return txt
 }
 
 // ShowInfoDialog mostra un dialog informativo
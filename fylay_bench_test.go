@@ -28,7 +28,7 @@ func BenchmarkComputeStyle(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = builder.computeStyle(elem)
+		_ = builder.ComputeStyle(elem)
 	}
 }
 
@@ -53,7 +53,7 @@ func BenchmarkComputeStyleClassOnly(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = builder.computeStyle(elem)
+		_ = builder.ComputeStyle(elem)
 	}
 }
 
@@ -77,7 +77,7 @@ func BenchmarkComputeStyleInlineOnly(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = builder.computeStyle(elem)
+		_ = builder.ComputeStyle(elem)
 	}
 }
 
@@ -133,16 +133,6 @@ func BenchmarkParseColorRGB(b *testing.B) {
 	}
 }
 
-// BenchmarkParseCSS measures performance of CSS parsing
-func BenchmarkParseCSS(b *testing.B) {
-	css := "color: red; font-size: 14px; padding: 10px; margin: 5px; background: white; border: 1px solid black;"
-
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_ = parseCSS(css)
-	}
-}
-
 // BenchmarkBuildLayout measures overall layout building performance
 func BenchmarkBuildLayout(b *testing.B) {
 	xml := `
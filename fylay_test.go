@@ -8,55 +8,6 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
-func TestParseCSS(t *testing.T) {
-	tests := []struct {
-		name     string
-		css      string
-		expected map[string]string
-	}{
-		{
-			name: "Simple CSS",
-			css:  "font-size: 16; color: red;",
-			expected: map[string]string{
-				"font-size": "16",
-				"color":     "red",
-			},
-		},
-		{
-			name: "CSS with braces",
-			css:  "{ font-weight: bold; text-align: center; }",
-			expected: map[string]string{
-				"font-weight": "bold",
-				"text-align":  "center",
-			},
-		},
-		{
-			name: "CSS with spaces",
-			css:  "  background-color: #FF0000  ;  width: 300  ",
-			expected: map[string]string{
-				"background-color": "#FF0000",
-				"width":            "300",
-			},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := parseCSS(tt.css)
-
-			if len(result) != len(tt.expected) {
-				t.Errorf("Expected %d properties, got %d", len(tt.expected), len(result))
-			}
-
-			for key, expectedValue := range tt.expected {
-				if result[key] != expectedValue {
-					t.Errorf("For key %s: expected %s, got %s", key, expectedValue, result[key])
-				}
-			}
-		})
-	}
-}
-
 func TestParseColor(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -92,40 +43,6 @@ func TestParseColor(t *testing.T) {
 	}
 }
 
-func TestParseSize(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    string
-		expected float32
-		hasError bool
-	}{
-		{"Simple number", "100", 100, false},
-		{"With px", "200px", 200, false},
-		{"Float", "15.5", 15.5, false},
-		{"With spaces", "  50  ", 50, false},
-		{"Invalid", "abc", 0, true},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result, err := parseSize(tt.input)
-
-			if tt.hasError {
-				if err == nil {
-					t.Error("Expected error but got none")
-				}
-			} else {
-				if err != nil {
-					t.Errorf("Unexpected error: %v", err)
-				}
-				if result != tt.expected {
-					t.Errorf("Expected %f, got %f", tt.expected, result)
-				}
-			}
-		})
-	}
-}
-
 func TestLoadLayout(t *testing.T) {
 	layoutXML := `
 <Layout>
@@ -153,11 +70,11 @@ func TestLoadLayout(t *testing.T) {
 	}
 
 	// Verifica che gli stili siano stati parsati
-	if len(builder.styles) == 0 {
+	if len(builder.GetStyles()) == 0 {
 		t.Error("Expected styles to be parsed")
 	}
 
-	if style, ok := builder.styles[".title"]; ok {
+	if style, ok := builder.GetStyles()[".title"]; ok {
 		if style.Properties["font-size"] != "20" {
 			t.Errorf("Expected font-size 20, got %s", style.Properties["font-size"])
 		}
@@ -0,0 +1,252 @@
+// Package fylaytest replays recorded fylay event sessions with the Fyne test
+// driver, so event logs attached to bug reports can be turned into tests.
+//
+//	app := test.NewApp()
+//	builder := fylay.NewBuilder()
+//	// ... load and build the layout, register the handlers ...
+//	err := fylaytest.ReplayLog(builder, logFile)
+package fylaytest
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/sandrolain/fylay"
+)
+
+// ReplayLog reads an event log written by fylay.EventRecorder and replays it
+func ReplayLog(b *fylay.Builder, r io.Reader) error {
+	events, err := fylay.ReadEventLog(r)
+	if err != nil {
+		return err
+	}
+	return Replay(b, events)
+}
+
+// Replay drives the recorded events, in order, against the widgets built by b.
+// Timestamps are ignored. Events are simulated on the widget with the same
+// ID, so the layout must give an ID to every element whose events are replayed.
+func Replay(b *fylay.Builder, events []fylay.TraceEvent) error {
+	for i, ev := range events {
+		if err := replayEvent(b, ev); err != nil {
+			return fmt.Errorf("event %d (%s on %q): %w", i+1, ev.Type, ev.TargetID, err)
+		}
+	}
+	return nil
+}
+
+// replayEvent simulates a single event
+func replayEvent(b *fylay.Builder, ev fylay.TraceEvent) error {
+	if ev.TargetID == "" {
+		return fmt.Errorf("event has no target ID")
+	}
+
+	w := b.GetWidget(ev.TargetID)
+	if w == nil {
+		return fmt.Errorf("no element with id %q", ev.TargetID)
+	}
+
+	switch ev.Type {
+	case "onclick":
+		btn, ok := w.(*widget.Button)
+		if !ok {
+			return fmt.Errorf("%T is not a button", w)
+		}
+		test.Tap(btn)
+		return nil
+	case "onchange":
+		return replayChange(w, ev.Value)
+	case "onsubmit":
+		if form, ok := w.(*widget.Form); ok && form.OnSubmit != nil {
+			// The values come from the field events replayed before
+			form.OnSubmit()
+			return nil
+		}
+		entry, ok := w.(*widget.Entry)
+		if !ok || entry.OnSubmitted == nil {
+			return fmt.Errorf("%T does not submit", w)
+		}
+		// The text is restored without firing onchange events, the recorded
+		// ones are replayed on their own
+		if entry.Text != ev.Value {
+			onChanged := entry.OnChanged
+			entry.OnChanged = nil
+			entry.SetText(ev.Value)
+			entry.OnChanged = onChanged
+		}
+		entry.OnSubmitted(ev.Value)
+		return nil
+	case "oncancel":
+		form, ok := w.(*widget.Form)
+		if !ok || form.OnCancel == nil {
+			return fmt.Errorf("%T does not cancel", w)
+		}
+		form.OnCancel()
+		return nil
+	case "onselect":
+		return replaySelect(b, ev.TargetID, w, ev.Value)
+	case "onopen", "onclose":
+		tree, ok := w.(*widget.Tree)
+		if !ok {
+			return fmt.Errorf("%T is not a tree", w)
+		}
+		if ev.Type == "onopen" {
+			tree.OpenBranch(ev.Value)
+		} else {
+			tree.CloseBranch(ev.Value)
+		}
+		return nil
+	}
+
+	// Interaction events are handled by the object placed in the layout,
+	// which may wrap the widget
+	return replayInteraction(b.GetElement(ev.TargetID), ev)
+}
+
+// replayChange applies the recorded value to a widget with an onchange event
+func replayChange(w fyne.CanvasObject, value string) error {
+	switch w := w.(type) {
+	case *widget.Entry:
+		w.SetText(value)
+	case *widget.Select:
+		w.SetSelected(value)
+	case *widget.RadioGroup:
+		w.SetSelected(value)
+	case *widget.CheckGroup:
+		var selected []string
+		if value != "" {
+			selected = strings.Split(value, ",")
+		}
+		w.SetSelected(selected)
+	case *widget.Check:
+		checked, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		w.SetChecked(checked)
+	case *widget.Slider:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		w.SetValue(v)
+	default:
+		return fmt.Errorf("cannot replay change on %T", w)
+	}
+	return nil
+}
+
+// replaySelect selects the recorded row or node of a widget with an onselect event
+func replaySelect(b *fylay.Builder, id string, w fyne.CanvasObject, value string) error {
+	switch w := w.(type) {
+	case *widget.List:
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		w.UnselectAll()
+		w.Select(id)
+	case *widget.Table:
+		// The recorded value is the index in the bound list, shown in
+		// another row when the table is sorted
+		index, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		row := b.TableRow(id, index)
+		if row < 0 {
+			return fmt.Errorf("table has no row %d", index)
+		}
+		w.UnselectAll()
+		w.Select(widget.TableCellID{Row: row})
+	case *widget.Tree:
+		w.UnselectAll()
+		w.Select(value)
+	default:
+		return fmt.Errorf("cannot replay select on %T", w)
+	}
+	return nil
+}
+
+// replayInteraction simulates focus, pointer and key events on the first
+// object in obj that supports them
+func replayInteraction(obj fyne.CanvasObject, ev fylay.TraceEvent) error {
+	switch ev.Type {
+	case "onfocus", "onblur", "onkey", "onhover", "onleave", "ondoubletap", "onsecondarytap":
+	default:
+		return fmt.Errorf("unsupported event type")
+	}
+
+	if findObject(obj, func(o fyne.CanvasObject) bool { return simulate(o, ev) }) == nil {
+		return fmt.Errorf("element does not handle %s", ev.Type)
+	}
+	return nil
+}
+
+// simulate simulates an interaction event on o, reporting whether o supports it
+func simulate(o fyne.CanvasObject, ev fylay.TraceEvent) bool {
+	switch ev.Type {
+	case "onfocus", "onblur", "onkey":
+		f, ok := o.(fyne.Focusable)
+		if !ok {
+			return false
+		}
+		switch ev.Type {
+		case "onfocus":
+			f.FocusGained()
+		case "onblur":
+			f.FocusLost()
+		default:
+			f.TypedKey(&fyne.KeyEvent{Name: fyne.KeyName(ev.Value)})
+		}
+	case "onhover", "onleave":
+		h, ok := o.(desktop.Hoverable)
+		if !ok {
+			return false
+		}
+		if ev.Type == "onhover" {
+			h.MouseIn(&desktop.MouseEvent{})
+		} else {
+			h.MouseOut()
+		}
+	case "ondoubletap":
+		d, ok := o.(fyne.DoubleTappable)
+		if !ok {
+			return false
+		}
+		test.DoubleTap(d)
+	case "onsecondarytap":
+		st, ok := o.(fyne.SecondaryTappable)
+		if !ok {
+			return false
+		}
+		test.TapSecondary(st)
+	}
+	return true
+}
+
+// findObject returns the first object in obj and its container children for
+// which match returns true
+func findObject(obj fyne.CanvasObject, match func(fyne.CanvasObject) bool) fyne.CanvasObject {
+	if obj == nil {
+		return nil
+	}
+	if match(obj) {
+		return obj
+	}
+	if c, ok := obj.(*fyne.Container); ok {
+		for _, child := range c.Objects {
+			if found := findObject(child, match); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package fylaytest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/sandrolain/fylay"
+)
+
+const layoutXML = `
+<Layout>
+	<VBox>
+		<Entry id="name" onchange="onName" onsubmit="onSubmit" />
+		<Checkbox id="agree" onchange="onAgree" label="Agree" />
+		<Slider id="volume" onchange="onVolume" min="0" max="10" />
+		<Button id="save" onclick="onSave" onsecondarytap="onMenu">Save</Button>
+		<Label id="info" onhover="onInfo" onkey="onInfo">Info</Label>
+		<List id="files" bind="files" onselect="onFile" />
+	</VBox>
+</Layout>
+`
+
+// newSession builds the test layout and records the handled events
+func newSession(t *testing.T) (*fylay.Builder, *[]string) {
+	t.Helper()
+
+	builder := fylay.NewBuilder()
+	builder.GetBindingContext().BindStringList("files", []string{"a.txt", "b.txt"})
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	var handled []string
+	for _, name := range []string{"onName", "onSubmit", "onAgree", "onVolume", "onSave", "onMenu", "onInfo", "onFile"} {
+		builder.On(name, func(ctx *fylay.EventContext) {
+			handled = append(handled, ctx.TargetID+":"+ctx.Type+":"+ctx.Value)
+		})
+	}
+	return builder, &handled
+}
+
+func TestReplay(t *testing.T) {
+	_ = test.NewApp()
+
+	builder, recorded := newSession(t)
+	var log bytes.Buffer
+	builder.SetEventTracer(fylay.NewEventRecorder(&log).Trace)
+
+	entry := builder.GetWidget("name").(*widget.Entry)
+	entry.SetText("ada")
+	entry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	builder.GetWidget("agree").(*widget.Check).SetChecked(true)
+	builder.GetWidget("volume").(*widget.Slider).SetValue(4)
+	test.Tap(builder.GetWidget("save").(*widget.Button))
+	test.TapSecondary(builder.GetElement("save").(fyne.SecondaryTappable))
+	builder.GetElement("info").(desktop.Hoverable).MouseIn(&desktop.MouseEvent{})
+	builder.GetElement("info").(fyne.Focusable).TypedKey(&fyne.KeyEvent{Name: fyne.KeyF1})
+	builder.GetWidget("files").(*widget.List).Select(1)
+
+	replayed, replayedEvents := newSession(t)
+	if err := ReplayLog(replayed, &log); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	if strings.Join(*replayedEvents, ",") != strings.Join(*recorded, ",") {
+		t.Errorf("Replayed events differ:\n got %v\nwant %v", *replayedEvents, *recorded)
+	}
+	if len(*recorded) != 9 {
+		t.Errorf("Expected 9 recorded events, got %v", *recorded)
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	_ = test.NewApp()
+	builder, _ := newSession(t)
+
+	tests := []fylay.TraceEvent{
+		{Type: "onclick"},
+		{Type: "onclick", TargetID: "missing"},
+		{Type: "onclick", TargetID: "name"},
+		{Type: "onchange", TargetID: "agree", Value: "maybe"},
+		{Type: "ondrop", TargetID: "info"},
+		{Type: "onsecondarytap", TargetID: "agree"},
+	}
+
+	for _, ev := range tests {
+		if err := Replay(builder, []fylay.TraceEvent{ev}); err == nil {
+			t.Errorf("Expected error replaying %+v", ev)
+		}
+	}
+}
+
+func TestReplaySortedTable(t *testing.T) {
+	_ = test.NewApp()
+
+	const tableXML = `
+<Layout>
+	<Table id="people" bind="people" onselect="onPerson">
+		<Column header="Name" field="name" />
+	</Table>
+</Layout>
+`
+	// newTable builds the table sorted by name and records the selections
+	newTable := func() (*fylay.Builder, *[]string) {
+		builder := fylay.NewBuilder()
+		builder.GetBindingContext().BindList("people", []any{
+			map[string]any{"name": "Grace"},
+			map[string]any{"name": "Ada"},
+		})
+		layout, err := builder.LoadLayout(strings.NewReader(tableXML))
+		if err != nil {
+			t.Fatalf("Failed to load layout: %v", err)
+		}
+		if _, err := builder.Build(layout); err != nil {
+			t.Fatalf("Failed to build: %v", err)
+		}
+
+		var selected []string
+		builder.On("onPerson", func(ctx *fylay.EventContext) {
+			selected = append(selected, ctx.Value)
+		})
+
+		table := builder.GetWidget("people").(*widget.Table)
+		header := table.CreateHeader().(*widget.Button)
+		table.UpdateHeader(widget.TableCellID{Row: -1, Col: 0}, header)
+		test.Tap(header)
+		return builder, &selected
+	}
+
+	builder, recorded := newTable()
+	var log bytes.Buffer
+	builder.SetEventTracer(fylay.NewEventRecorder(&log).Trace)
+	builder.GetWidget("people").(*widget.Table).Select(widget.TableCellID{Row: 0})
+
+	replayed, replayedSelections := newTable()
+	if err := ReplayLog(replayed, &log); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	if strings.Join(*recorded, ",") != "1" {
+		t.Fatalf("Expected the first sorted row to be item 1, got %v", *recorded)
+	}
+	if strings.Join(*replayedSelections, ",") != "1" {
+		t.Errorf("Expected the replay to select item 1, got %v", *replayedSelections)
+	}
+}
+
+func TestReplaySubmitText(t *testing.T) {
+	_ = test.NewApp()
+	builder, handled := newSession(t)
+
+	events := []fylay.TraceEvent{{Type: "onsubmit", TargetID: "name", Value: "bob"}}
+	if err := Replay(builder, events); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	if got := strings.Join(*handled, ","); got != "name:onsubmit:bob" {
+		t.Errorf("Expected only the recorded submit, got %v", got)
+	}
+	if text := builder.GetWidget("name").(*widget.Entry).Text; text != "bob" {
+		t.Errorf("Expected the submitted text to be restored, got %q", text)
+	}
+}
@@ -8,9 +8,12 @@ import (
 
 	"fyne.io/fyne/v2"
 	"github.com/fsnotify/fsnotify"
+
+	"github.com/sandrolain/fylay/core"
 )
 
-// HotReloadConfig configura il comportamento del hot reload
+// HotReloadConfig configura il comportamento del hot reload.
+// OnReload e OnError vengono chiamate sul thread principale di Fyne.
 type HotReloadConfig struct {
 	Enabled     bool
 	LayoutPath  string
@@ -94,11 +97,7 @@ func (b *Builder) watchFileChanges(config *HotReloadConfig) {
 
 				// Reload layout
 				if err := b.reloadLayout(config); err != nil {
-					if config.OnError != nil {
-						config.OnError(err)
-					} else if config.DebugLog {
-						log.Printf("[HotReload] Error reloading: %v\n", err)
-					}
+					config.reportError(err)
 				}
 			}
 
@@ -107,11 +106,7 @@ func (b *Builder) watchFileChanges(config *HotReloadConfig) {
 				return
 			}
 
-			if config.OnError != nil {
-				config.OnError(fmt.Errorf("watcher error: %w", err))
-			} else if config.DebugLog {
-				log.Printf("[HotReload] Watcher error: %v\n", err)
-			}
+			config.reportError(fmt.Errorf("watcher error: %w", err))
 
 		case <-config.stopChannel:
 			if config.DebugLog {
@@ -122,29 +117,34 @@ func (b *Builder) watchFileChanges(config *HotReloadConfig) {
 	}
 }
 
-// reloadLayout reloads the layout file and calls the callback
+// reloadLayout reloads the layout file and calls the callback.
+// The file is parsed on the calling goroutine; widgets are built and
+// handed to OnReload on the Fyne main thread.
 func (b *Builder) reloadLayout(config *HotReloadConfig) error {
-	// Load new layout
-	newBuilder, content, err := LoadLayoutFromFile(config.LayoutPath)
+	// Parse the new layout first so a broken file leaves the current UI untouched
+	layout, err := core.ParseLayoutFile(config.LayoutPath)
 	if err != nil {
 		return fmt.Errorf("failed to load layout: %w", err)
 	}
 
-	// Copy event handlers and contexts from current builder
-	newBuilder.eventHandler = b.eventHandler
-	newBuilder.eventCallbacks = b.eventCallbacks
-	newBuilder.entryCallbacks = b.entryCallbacks
-	newBuilder.bindingContext = b.bindingContext
-	newBuilder.templateContext = b.templateContext
+	// Rebuild on the same builder, keeping event handlers and contexts.
+	// The previous elements stay visible to GetWidget until the rebuild succeeds.
+	fyne.DoAndWait(func() {
+		var content fyne.CanvasObject
+		content, err = b.Rebuild(layout)
+		if err != nil {
+			return
+		}
 
-	// Call reload callback
-	if config.OnReload != nil {
-		config.OnReload(content)
+		// Call reload callback
+		if config.OnReload != nil {
+			config.OnReload(content)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build layout: %w", err)
 	}
 
-	// Update current builder reference
-	*b = *newBuilder
-
 	if config.DebugLog {
 		log.Println("[HotReload] Layout reloaded successfully")
 	}
@@ -152,6 +152,17 @@ func (b *Builder) reloadLayout(config *HotReloadConfig) error {
 	return nil
 }
 
+// reportError passes a hot reload error to OnError on the Fyne main thread
+func (config *HotReloadConfig) reportError(err error) {
+	if config.OnError != nil {
+		fyne.Do(func() {
+			config.OnError(err)
+		})
+	} else if config.DebugLog {
+		log.Printf("[HotReload] Error: %v\n", err)
+	}
+}
+
 // StopHotReload ferma il hot reload
 func (config *HotReloadConfig) Stop() {
 	if config.stopChannel != nil {
@@ -160,7 +171,9 @@ func (config *HotReloadConfig) Stop() {
 	}
 }
 
-// SimpleHotReload è una funzione helper per casi d'uso semplici
+// SimpleHotReload è una funzione helper per casi d'uso semplici. La
+// callback di chiusura della finestra resta quella dell'applicazione: per
+// annullare alla chiusura le callback asincrone si usa BindWindow.
 func (b *Builder) SimpleHotReload(layoutPath string, window fyne.Window) error {
 	config := NewHotReloadConfig(layoutPath)
 	config.DebugLog = true
@@ -0,0 +1,188 @@
+package fylay
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// interpolation is a text with ${...} placeholders, e.g.
+// "Hello ${user.Name}, you have ${count} items". A placeholder holds an
+// expression, optionally followed by converter pipes: ${price | currency:EUR}.
+type interpolation struct {
+	parts []interpolationPart
+	keys  []string // Binding keys used by the placeholders
+}
+
+// interpolationPart is a literal text or a placeholder
+type interpolationPart struct {
+	text  string
+	expr  *expr
+	pipes []bindPipe
+}
+
+// hasInterpolation reports whether a text contains placeholders
+func hasInterpolation(text string) bool {
+	return strings.Contains(text, "${")
+}
+
+// parseInterpolation parses a text with ${...} placeholders
+func parseInterpolation(text string) (*interpolation, error) {
+	interp := &interpolation{}
+	seen := make(map[string]bool)
+
+	for {
+		start := strings.Index(text, "${")
+		if start < 0 {
+			break
+		}
+		end := placeholderEnd(text, start+2)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated placeholder in %q", text)
+		}
+
+		if start > 0 {
+			interp.parts = append(interp.parts, interpolationPart{text: text[:start]})
+		}
+
+		src, pipes := splitPipes(text[start+2 : end])
+		e, err := parseExpr(src)
+		if err != nil {
+			return nil, err
+		}
+		interp.parts = append(interp.parts, interpolationPart{expr: e, pipes: pipes})
+		for _, key := range e.keys {
+			if !seen[key] {
+				seen[key] = true
+				interp.keys = append(interp.keys, key)
+			}
+		}
+
+		text = text[end+1:]
+	}
+
+	if text != "" {
+		interp.parts = append(interp.parts, interpolationPart{text: text})
+	}
+
+	return interp, nil
+}
+
+// placeholderEnd returns the index of the brace closing a placeholder,
+// ignoring braces in quoted strings, or -1
+func placeholderEnd(text string, from int) int {
+	var quote byte
+	for i := from; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+// splitPipes splits the converter pipes off a placeholder expression.
+// Pipes are separated by a single |, so the || operator is left alone.
+func splitPipes(src string) (string, []bindPipe) {
+	var quote byte
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '|':
+			if i+1 < len(src) && src[i+1] == '|' {
+				i++
+				continue
+			}
+			_, pipes := parseBindExpr(src[i:])
+			return src[:i], pipes
+		}
+	}
+	return src, nil
+}
+
+// render formats the interpolation with the values of its keys, in order.
+// Placeholders that fail to evaluate are left empty.
+func (interp *interpolation) render(values []any) any {
+	vars := make(map[string]any, len(values))
+	for i, key := range interp.keys {
+		vars[key] = values[i]
+	}
+
+	var sb strings.Builder
+	for _, part := range interp.parts {
+		if part.expr == nil {
+			sb.WriteString(part.text)
+			continue
+		}
+
+		v, err := part.expr.eval(vars)
+		for _, p := range part.pipes {
+			if err != nil {
+				break
+			}
+			v, err = p.conv.to(v, p.arg)
+		}
+		if err != nil {
+			log.Printf("fylay: placeholder ${%s}: %v", part.expr.src, err)
+			continue
+		}
+		sb.WriteString(formatValue(v))
+	}
+
+	return sb.String()
+}
+
+// interpolate returns a string binding holding the text of an element with
+// its placeholders replaced. It stops following the bindings they use when
+// the built tree holding the element is replaced.
+func (b *Builder) interpolate(text string) binding.String {
+	data, c := b.GetBindingContext().interpolate(text)
+	if c != nil {
+		b.buildingScope().onRelease(c.stop)
+	}
+	return data
+}
+
+// interpolate returns a string binding holding the text with its
+// placeholders replaced, updated by the returned computed value whenever a
+// binding they use changes. It returns nil if the text is not a valid
+// interpolation.
+func (bc *BindingContext) interpolate(text string) (binding.String, *computed) {
+	interp, err := parseInterpolation(text)
+	if err == nil {
+		for _, part := range interp.parts {
+			if err = bc.resolvePipes(part.pipes); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		log.Printf("fylay: invalid interpolation %q: %v", text, err)
+		return nil, nil
+	}
+
+	result := binding.NewString()
+	_ = setDataValue(result, compute(bc.dependencies(interp.keys), interp.render)) //nolint:errcheck // Strings always set
+	c := bc.watch(interp.keys, interp.render, result, "text")
+
+	return result, c
+}
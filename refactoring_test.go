@@ -1,53 +1,10 @@
 package fylay
 
 import (
-	"bytes"
 	"image/color"
 	"testing"
 )
 
-// TestStyleCascade verifica la precedenza degli stili (class < id < inline)
-func TestStyleCascade(t *testing.T) {
-	xml := `
-		<Layout>
-			<Style selector=".red-text">color: red;</Style>
-			<Style selector="#special">color: blue;</Style>
-			<VBox class="red-text" id="special" style="color: green;">
-				<Label>Test</Label>
-			</VBox>
-		</Layout>
-	`
-
-	builder := NewBuilder()
-	layout, err := builder.LoadLayout(bytes.NewReader([]byte(xml)))
-	if err != nil {
-		t.Fatalf("Failed to parse layout: %v", err)
-	}
-
-	// Test cascade: inline > id > class
-	elem := layout.Root
-	style := builder.computeStyle(elem)
-
-	// Inline style should win
-	if style["color"] != "green" {
-		t.Errorf("Expected inline style 'green', got '%s'", style["color"])
-	}
-
-	// Test without inline style - ID should win
-	elem.Style = ""
-	style = builder.computeStyle(elem)
-	if style["color"] != "blue" {
-		t.Errorf("Expected ID style 'blue', got '%s'", style["color"])
-	}
-
-	// Test without inline and ID - class should apply
-	elem.ID = ""
-	style = builder.computeStyle(elem)
-	if style["color"] != "red" {
-		t.Errorf("Expected class style 'red', got '%s'", style["color"])
-	}
-}
-
 // TestColorParserStrategies verifies each color parser independently
 func TestColorParserStrategies(t *testing.T) {
 	tests := []struct {
@@ -205,97 +162,3 @@ func TestRGBColorParser(t *testing.T) {
 		})
 	}
 }
-
-// TestApplyStyleMethods tests individual style application methods
-func TestApplyStyleMethods(t *testing.T) {
-	builder := NewBuilder()
-
-	// Setup test styles
-	builder.styles = map[string]Style{
-		".red": {
-			Selector:   ".red",
-			Properties: map[string]string{"color": "red", "font-size": "14"},
-		},
-		".large": {
-			Selector:   ".large",
-			Properties: map[string]string{"font-size": "20"},
-		},
-		"#unique": {
-			Selector:   "#unique",
-			Properties: map[string]string{"color": "blue"},
-		},
-	}
-
-	t.Run("applyClassStyles - single class", func(t *testing.T) {
-		style := make(map[string]string)
-		builder.applyClassStyles(&style, "red")
-
-		if style["color"] != "red" {
-			t.Errorf("Expected color 'red', got '%s'", style["color"])
-		}
-		if style["font-size"] != "14" {
-			t.Errorf("Expected font-size '14', got '%s'", style["font-size"])
-		}
-	})
-
-	t.Run("applyClassStyles - multiple classes", func(t *testing.T) {
-		style := make(map[string]string)
-		builder.applyClassStyles(&style, "red large")
-
-		// 'large' should override 'red' font-size
-		if style["color"] != "red" {
-			t.Errorf("Expected color 'red', got '%s'", style["color"])
-		}
-		if style["font-size"] != "20" {
-			t.Errorf("Expected font-size '20', got '%s'", style["font-size"])
-		}
-	})
-
-	t.Run("applyClassStyles - empty", func(t *testing.T) {
-		style := make(map[string]string)
-		builder.applyClassStyles(&style, "")
-
-		if len(style) != 0 {
-			t.Errorf("Expected empty style, got %v", style)
-		}
-	})
-
-	t.Run("applyIDStyles", func(t *testing.T) {
-		style := make(map[string]string)
-		builder.applyIDStyles(&style, "unique")
-
-		if style["color"] != "blue" {
-			t.Errorf("Expected color 'blue', got '%s'", style["color"])
-		}
-	})
-
-	t.Run("applyIDStyles - empty", func(t *testing.T) {
-		style := make(map[string]string)
-		builder.applyIDStyles(&style, "")
-
-		if len(style) != 0 {
-			t.Errorf("Expected empty style, got %v", style)
-		}
-	})
-
-	t.Run("applyInlineStyles", func(t *testing.T) {
-		style := make(map[string]string)
-		builder.applyInlineStyles(&style, "color: green; font-weight: bold")
-
-		if style["color"] != "green" {
-			t.Errorf("Expected color 'green', got '%s'", style["color"])
-		}
-		if style["font-weight"] != "bold" {
-			t.Errorf("Expected font-weight 'bold', got '%s'", style["font-weight"])
-		}
-	})
-
-	t.Run("applyInlineStyles - empty", func(t *testing.T) {
-		style := make(map[string]string)
-		builder.applyInlineStyles(&style, "")
-
-		if len(style) != 0 {
-			t.Errorf("Expected empty style, got %v", style)
-		}
-	})
-}
@@ -3,11 +3,14 @@ package fylay
 import (
 	"bytes"
 	"fmt"
+	"sync"
 	"text/template"
 )
 
-// TemplateContext holds template variables and functions
+// TemplateContext holds template variables and functions.
+// It is safe for concurrent use.
 type TemplateContext struct {
+	mu        sync.RWMutex
 	variables map[string]interface{}
 	funcs     template.FuncMap
 }
@@ -22,22 +25,31 @@ func NewTemplateContext() *TemplateContext {
 
 // SetVariable sets a template variable
 func (tc *TemplateContext) SetVariable(key string, value interface{}) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
 	tc.variables[key] = value
 }
 
 // GetVariable gets a template variable
 func (tc *TemplateContext) GetVariable(key string) (interface{}, bool) {
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
 	val, ok := tc.variables[key]
 	return val, ok
 }
 
 // SetFunc adds a custom template function
 func (tc *TemplateContext) SetFunc(name string, fn interface{}) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
 	tc.funcs[name] = fn
 }
 
 // ProcessTemplate processes a template string with the context
 func (tc *TemplateContext) ProcessTemplate(tmplStr string) (string, error) {
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+
 	tmpl, err := template.New("layout").Funcs(tc.funcs).Parse(tmplStr)
 	if err != nil {
 		return "", fmt.Errorf("template parse error: %w", err)
@@ -51,6 +63,13 @@ func (tc *TemplateContext) ProcessTemplate(tmplStr string) (string, error) {
 	return buf.String(), nil
 }
 
+// isEmpty reports whether the context has no variables
+func (tc *TemplateContext) isEmpty() bool {
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	return len(tc.variables) == 0
+}
+
 // ProcessXMLWithTemplate processes XML content replacing template variables
 func ProcessXMLWithTemplate(xmlContent string, ctx *TemplateContext) (string, error) {
 	if ctx == nil {
@@ -62,11 +81,15 @@ func ProcessXMLWithTemplate(xmlContent string, ctx *TemplateContext) (string, er
 
 // SetTemplateContext sets the template context on the builder
 func (b *Builder) SetTemplateContext(ctx *TemplateContext) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	b.templateContext = ctx
 }
 
 // GetTemplateContext returns the builder's template context
 func (b *Builder) GetTemplateContext() *TemplateContext {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	if b.templateContext == nil {
 		b.templateContext = NewTemplateContext()
 	}
@@ -82,7 +105,7 @@ func (b *Builder) SetTemplateVariable(key string, value interface{}) {
 // ProcessLayoutTemplate processes the layout with template variables
 func (b *Builder) ProcessLayoutTemplate(xmlContent []byte) ([]byte, error) {
 	ctx := b.GetTemplateContext()
-	if ctx == nil || len(ctx.variables) == 0 {
+	if ctx == nil || ctx.isEmpty() {
 		return xmlContent, nil
 	}
 
@@ -0,0 +1,20 @@
+package fylay
+
+import "fyne.io/fyne/v2"
+
+// Update runs fn on the Fyne main thread without waiting for it.
+// Use it from background goroutines (timers, network calls, workers) to
+// modify widgets obtained with GetWidget or GetElement. Event callbacks
+// already run on the main thread and can update widgets directly.
+//
+// Values set through the BindingContext bindings are safe to change from
+// any goroutine: Fyne delivers the resulting widget updates on the main thread.
+func (b *Builder) Update(fn func()) {
+	fyne.Do(fn)
+}
+
+// UpdateAndWait runs fn on the Fyne main thread and waits for it to complete.
+// It must not be called from the main thread itself.
+func (b *Builder) UpdateAndWait(fn func()) {
+	fyne.DoAndWait(fn)
+}
@@ -0,0 +1,95 @@
+package fylay
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestBuilderUpdate(t *testing.T) {
+	_ = test.NewApp()
+
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(`<Layout><Label id="status">Idle</Label></Layout>`))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		builder.UpdateAndWait(func() {
+			builder.GetWidget("status").(*widget.Label).SetText("Working")
+		})
+		builder.Update(func() {
+			builder.GetWidget("status").(*widget.Label).SetText("Done")
+			close(done)
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Update callback not run")
+	}
+
+	if text := builder.GetWidget("status").(*widget.Label).Text; text != "Done" {
+		t.Errorf("Expected label text Done, got %q", text)
+	}
+}
+
+func TestImageLoadAsync(t *testing.T) {
+	_ = test.NewApp()
+
+	var buf bytes.Buffer
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.White)
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("Failed to encode png: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(buf.Bytes())
+	}))
+	defer server.Close()
+
+	src := server.URL + "/logo.png"
+	iw := newImageWidget(src)
+
+	loaded := make(chan error, 1)
+	iw.LoadAsync(func(err error) {
+		loaded <- err
+	})
+
+	select {
+	case err := <-loaded:
+		if err != nil {
+			t.Fatalf("Async load failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Async load did not complete")
+	}
+
+	if iw.Resource == nil || !bytes.Equal(iw.Resource.Content(), buf.Bytes()) {
+		t.Error("Expected image resource to be set from downloaded data")
+	}
+
+	// Second load is served from the cache synchronously
+	cached := newImageWidget(src)
+	cached.LoadAsync(nil)
+	if cached.Resource == nil {
+		t.Error("Expected cached image to be applied immediately")
+	}
+}
@@ -0,0 +1,117 @@
+package fylay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// TraceEvent descrive un evento generato da un elemento del layout
+type TraceEvent struct {
+	Time     time.Time `json:"time"`
+	Type     string    `json:"type"`             // Attributo evento, es. "onclick"
+	Handler  string    `json:"handler"`          // Nome dell'handler
+	TargetID string    `json:"target,omitempty"` // ID dell'elemento
+	Element  string    `json:"element"`          // Tipo dell'elemento, es. "Button"
+	Value    string    `json:"value,omitempty"`
+	Args     []any     `json:"args,omitempty"`
+	Handled  bool      `json:"handled"` // false se nessuna callback era registrata
+}
+
+// EventTracer riceve ogni evento generato dagli elementi, gestito o meno
+type EventTracer func(ev TraceEvent)
+
+// SetEventTracer imposta il tracer degli eventi; nil lo disattiva.
+// Il tracer è chiamato prima della callback, sul thread che genera l'evento.
+func (b *Builder) SetEventTracer(tracer EventTracer) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.eventTracer = tracer
+}
+
+// traceEvent passa l'evento al tracer, se impostato
+func (b *Builder) traceEvent(ctx *EventContext, handled bool) {
+	b.mu.RLock()
+	tracer := b.eventTracer
+	b.mu.RUnlock()
+
+	if tracer == nil {
+		return
+	}
+
+	tracer(TraceEvent{
+		Time:     time.Now(),
+		Type:     ctx.Type,
+		Handler:  ctx.EventName,
+		TargetID: ctx.TargetID,
+		Element:  ctx.Element.XMLName.Local,
+		Value:    ctx.Value,
+		Args:     ctx.Args,
+		Handled:  handled,
+	})
+}
+
+// EventRecorder registra gli eventi in formato JSON Lines, un evento per riga.
+// È sicuro per l'uso concorrente.
+//
+//	rec := fylay.NewEventRecorder(file)
+//	builder.SetEventTracer(rec.Trace)
+type EventRecorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewEventRecorder crea un recorder che scrive su w
+func NewEventRecorder(w io.Writer) *EventRecorder {
+	return &EventRecorder{enc: json.NewEncoder(w)}
+}
+
+// Trace registra un evento; può essere usato come EventTracer
+func (r *EventRecorder) Trace(ev TraceEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return
+	}
+	if err := r.enc.Encode(ev); err != nil {
+		r.err = fmt.Errorf("failed to record event: %w", err)
+	}
+}
+
+// Err restituisce il primo errore di scrittura, dopo il quale il recorder
+// smette di registrare
+func (r *EventRecorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// ReadEventLog legge gli eventi registrati da un EventRecorder
+func ReadEventLog(r io.Reader) ([]TraceEvent, error) {
+	var events []TraceEvent
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var ev TraceEvent
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			return nil, fmt.Errorf("invalid event at line %d: %w", line, err)
+		}
+		events = append(events, ev)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read event log: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,52 @@
+package fylay
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestEventTracerAndRecorder(t *testing.T) {
+	builder := buildEventsLayout(t)
+	builder.On("onSave", func(ctx *EventContext) {})
+
+	var buf bytes.Buffer
+	rec := NewEventRecorder(&buf)
+	builder.SetEventTracer(rec.Trace)
+
+	test.Tap(builder.GetWidget("saveBtn").(*widget.Button))
+	builder.GetWidget("agree").(*widget.Check).SetChecked(true)
+
+	builder.SetEventTracer(nil)
+	test.Tap(builder.GetWidget("saveBtn").(*widget.Button))
+
+	if err := rec.Err(); err != nil {
+		t.Fatalf("Recorder failed: %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 2 {
+		t.Fatalf("Expected 2 JSON lines, got %d:\n%s", lines, buf.String())
+	}
+
+	events, err := ReadEventLog(&buf)
+	if err != nil {
+		t.Fatalf("ReadEventLog failed: %v", err)
+	}
+
+	save, agree := events[0], events[1]
+	if save.Type != "onclick" || save.Handler != "onSave" || save.TargetID != "saveBtn" || save.Element != "Button" || !save.Handled {
+		t.Errorf("Unexpected click trace %+v", save)
+	}
+	if save.Time.IsZero() {
+		t.Error("Expected trace timestamp")
+	}
+	if agree.Type != "onchange" || agree.Handler != "onAgree" || agree.Value != "true" || agree.Handled {
+		t.Errorf("Unexpected unhandled change trace %+v", agree)
+	}
+
+	if _, err := ReadEventLog(strings.NewReader("{not json}\n")); err == nil {
+		t.Error("Expected error for malformed event log")
+	}
+}
@@ -0,0 +1,97 @@
+package fylay
+
+import (
+	"sort"
+	"strings"
+)
+
+// UnhandledEventsError è restituito da Build e Rebuild in modalità strict
+// quando il layout usa handler senza callback registrata
+type UnhandledEventsError struct {
+	Diagnostics []Diagnostic
+}
+
+// Error implementa l'interfaccia error
+func (e *UnhandledEventsError) Error() string {
+	msgs := make([]string, len(e.Diagnostics))
+	for i, d := range e.Diagnostics {
+		msgs[i] = d.String()
+	}
+	return "unhandled events: " + strings.Join(msgs, "; ")
+}
+
+// SetStrictEvents attiva la modalità strict: Build e Rebuild falliscono se il
+// layout usa handler senza callback registrata con On, OnAsync o
+// BindController. Le callback vanno quindi registrate prima di Build.
+// L'EventHandler non conta come callback.
+func (b *Builder) SetStrictEvents(strict bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.strictEvents = strict
+}
+
+// UnhandledEvents restituisce gli handler usati nell'ultimo layout costruito
+// senza una callback registrata, e quelli con un'espressione non valida
+func (b *Builder) UnhandledEvents() []Diagnostic {
+	return b.unhandledEvents(b.Layout())
+}
+
+// UnusedHandlers restituisce, in ordine alfabetico, le callback registrate
+// che l'ultimo layout costruito non usa. I metodi di un controller sono
+// segnalati una sola volta, con il nome del metodo, se il layout non usa
+// nessuno dei loro nomi.
+func (b *Builder) UnusedHandlers() []string {
+	refs := b.eventReferences()
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	used := make(map[string]bool)
+	for _, ref := range refs {
+		used[ref.expr.name] = true
+		if method, ok := b.handlerAliases[ref.expr.name]; ok {
+			used[method] = true
+		}
+	}
+
+	var unused []string
+	for name := range b.callbacks {
+		if _, alias := b.handlerAliases[name]; alias || used[name] {
+			continue
+		}
+		unused = append(unused, name)
+	}
+	sort.Strings(unused)
+	return unused
+}
+
+// unhandledEvents verifica gli handler usati in un layout
+func (b *Builder) unhandledEvents(layout *Layout) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, ref := range layoutEventReferences(layout) {
+		if ref.err != nil {
+			diagnostics = append(diagnostics, ref.diagnostic("invalid handler: %v", ref.err))
+			continue
+		}
+		if _, ok := b.callback(ref.expr.name); !ok {
+			diagnostics = append(diagnostics, ref.diagnostic("no handler registered for %q", ref.expr.name))
+		}
+	}
+	return diagnostics
+}
+
+// checkStrictEvents restituisce un errore se in modalità strict il layout
+// usa handler non registrati
+func (b *Builder) checkStrictEvents(layout *Layout) error {
+	b.mu.RLock()
+	strict := b.strictEvents
+	b.mu.RUnlock()
+
+	if !strict {
+		return nil
+	}
+	if diagnostics := b.unhandledEvents(layout); len(diagnostics) > 0 {
+		return &UnhandledEventsError{Diagnostics: diagnostics}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package fylay
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+)
+
+const validateLayoutXML = `
+<Layout>
+	<VBox>
+		<Button id="save" onclick="onSave">Save</Button>
+		<Entry id="name" onchange="onName" onsubmit="broken(" />
+		<Slider id="volume" onchange="setVolume(1)" />
+	</VBox>
+</Layout>
+`
+
+func TestUnhandledEvents(t *testing.T) {
+	_ = test.NewApp()
+
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(validateLayoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	builder.On("onSave", func(ctx *EventContext) {})
+	builder.On("setVolume", func(ctx *EventContext) {})
+	builder.On("onDelete", func(ctx *EventContext) {})
+	builder.On("onHelp", func(ctx *EventContext) {})
+
+	unhandled := builder.UnhandledEvents()
+	if len(unhandled) != 2 {
+		t.Fatalf("Expected 2 unhandled events, got %v", unhandled)
+	}
+	if d := unhandled[0]; d.ElementID != "name" || d.Attr != "onchange" || d.Handler != "onName" {
+		t.Errorf("Unexpected diagnostic %+v", d)
+	}
+	if d := unhandled[1]; d.Attr != "onsubmit" || !strings.Contains(d.Message, "invalid handler") {
+		t.Errorf("Unexpected diagnostic %+v", d)
+	}
+
+	if unused := builder.UnusedHandlers(); strings.Join(unused, ",") != "onDelete,onHelp" {
+		t.Errorf("Unexpected unused handlers %v", unused)
+	}
+}
+
+func TestStrictEvents(t *testing.T) {
+	_ = test.NewApp()
+
+	builder := NewBuilder()
+	builder.SetStrictEvents(true)
+	layout, err := builder.LoadLayout(strings.NewReader(validateLayoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+
+	_, err = builder.Build(layout)
+	var unhandled *UnhandledEventsError
+	if !errors.As(err, &unhandled) || len(unhandled.Diagnostics) != 4 {
+		t.Fatalf("Expected UnhandledEventsError with 4 diagnostics, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Button#save onclick") {
+		t.Errorf("Expected error to name the element, got %q", err)
+	}
+
+	builder.On("onSave", func(ctx *EventContext) {})
+	builder.On("onName", func(ctx *EventContext) {})
+	builder.On("setVolume", func(ctx *EventContext) {})
+	entry := &layout.Root.Children[1]
+	for i, attr := range entry.Attributes {
+		if attr.Name.Local == "onsubmit" {
+			entry.Attributes = append(entry.Attributes[:i], entry.Attributes[i+1:]...)
+			break
+		}
+	}
+
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Expected strict build to succeed once handlers are registered: %v", err)
+	}
+
+	// A reload referencing an unknown handler keeps the current layout
+	next, err := builder.LoadLayout(strings.NewReader(`<Layout><Button id="other" onclick="onOther" /></Layout>`))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Rebuild(next); err == nil {
+		t.Error("Expected strict rebuild to fail")
+	}
+	if builder.GetWidget("save") == nil || builder.GetWidget("other") != nil {
+		t.Error("Expected the current layout to stay in use")
+	}
+}
@@ -0,0 +1,268 @@
+package fylay
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// emailPattern è il formato accettato da type="email"
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// fieldValidation è la validazione di un campo del layout
+type fieldValidation struct {
+	validator fyne.StringValidator
+	value     func() string  // Valore corrente del campo
+	show      func(error)    // Mostra l'errore sul widget, può essere nil
+	errorData binding.String // Binding del messaggio di errore (errorBind), può essere nil
+	listeners []func()       // Notificati a ogni modifica del campo, sul thread Fyne
+}
+
+// RegisterValidator registra un validatore con nome, usato dai campi con
+// validate="nome". I nomi vengono risolti durante la validazione, quindi i
+// validatori possono essere registrati anche dopo Build.
+func (b *Builder) RegisterValidator(name string, validator fyne.StringValidator) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.validators[name] = validator
+}
+
+// Validate valida i campi con ID dell'ultimo layout costruito e restituisce
+// gli errori per ID, nil se sono tutti validi. Gli errori vengono mostrati
+// sui campi anche se l'utente non li ha ancora modificati.
+func (b *Builder) Validate() map[string]error {
+	var errs map[string]error
+	for id, field := range b.currentScope().allFields() {
+		if err := field.check(true); err != nil {
+			if errs == nil {
+				errs = make(map[string]error)
+			}
+			errs[id] = err
+		}
+	}
+	return errs
+}
+
+// check valida il valore corrente e aggiorna il messaggio di errore;
+// con reveal l'errore viene mostrato anche sul widget
+func (f *fieldValidation) check(reveal bool) error {
+	err := f.validator(f.value())
+	if reveal && f.show != nil {
+		f.show(err)
+	}
+	if f.errorData != nil {
+		msg := ""
+		if err != nil {
+			msg = err.Error()
+		}
+		_ = f.errorData.Set(msg) //nolint:errcheck // Le stringhe si impostano sempre
+	}
+	return err
+}
+
+// addField registra la validazione di un campo, nil se l'elemento non ha
+// regole. validator è il validatore già presente sul widget, può essere nil.
+func (b *Builder) addField(elem *Element, validator fyne.StringValidator, value func() string, show func(error)) *fieldValidation {
+	rules := b.fieldValidator(elem)
+	if rules == nil {
+		return nil
+	}
+	if validator != nil {
+		rules = chainValidators(validator, rules)
+	}
+
+	field := &fieldValidation{validator: rules, value: value, show: show}
+	if errorBind := elem.GetAttr("errorBind"); errorBind != "" {
+		field.errorData = b.GetBindingContext().stringBinding(errorBind, "")
+	}
+
+	// Solo i campi con ID sono validati da Validate
+	if elem.ID != "" {
+		b.buildingScope().addField(elem.ID, field)
+	}
+
+	return field
+}
+
+// changed aggiorna il messaggio di errore dopo una modifica del campo; può
+// essere chiamato su un campo nil
+func (f *fieldValidation) changed() {
+	if f == nil {
+		return
+	}
+	f.check(false) //nolint:errcheck // L'errore resta nel messaggio del campo
+	for _, listener := range f.listeners {
+		listener()
+	}
+}
+
+// valid valida il valore corrente senza mostrare l'errore
+func (f *fieldValidation) valid() error {
+	return f.validator(f.value())
+}
+
+// fieldValidator compone le regole di validazione indicate dagli attributi
+// dell'elemento: required, pattern, minlength, maxlength, min, max, type
+// (email, number, url) e validate, con i nomi dei validatori registrati
+// separati da virgole. I valori vuoti sono validi se il campo non è
+// required. errorMessage sostituisce il messaggio di qualsiasi regola.
+// Restituisce nil se l'elemento non ha regole.
+func (b *Builder) fieldValidator(elem *Element) fyne.StringValidator {
+	var rules []fyne.StringValidator
+
+	if pattern := elem.GetAttr("pattern"); pattern != "" {
+		// Come in HTML il pattern deve corrispondere all'intero valore
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			log.Printf("fylay: invalid pattern %q: %v", pattern, err)
+		} else {
+			rules = append(rules, func(value string) error {
+				if !re.MatchString(value) {
+					return errors.New("invalid format")
+				}
+				return nil
+			})
+		}
+	}
+
+	if n, err := strconv.Atoi(elem.GetAttr("minlength")); err == nil {
+		rules = append(rules, func(value string) error {
+			if utf8.RuneCountInString(value) < n {
+				return fmt.Errorf("must be at least %d characters", n)
+			}
+			return nil
+		})
+	}
+	if n, err := strconv.Atoi(elem.GetAttr("maxlength")); err == nil {
+		rules = append(rules, func(value string) error {
+			if utf8.RuneCountInString(value) > n {
+				return fmt.Errorf("must be at most %d characters", n)
+			}
+			return nil
+		})
+	}
+
+	switch kind := elem.GetAttr("type"); kind {
+	case "":
+	case "email":
+		rules = append(rules, func(value string) error {
+			if !emailPattern.MatchString(value) {
+				return errors.New("must be a valid email address")
+			}
+			return nil
+		})
+	case "number":
+		rules = append(rules, func(value string) error {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return errors.New("must be a number")
+			}
+			return nil
+		})
+	case "url":
+		rules = append(rules, func(value string) error {
+			if u, err := url.ParseRequestURI(value); err != nil || u.Scheme == "" || u.Host == "" {
+				return errors.New("must be a valid URL")
+			}
+			return nil
+		})
+	default:
+		log.Printf("fylay: unknown input type %q", kind)
+	}
+
+	if attr := elem.GetAttr("min"); attr != "" {
+		if limit, err := strconv.ParseFloat(attr, 64); err == nil {
+			rules = append(rules, numberRule(func(n float64) error {
+				if n < limit {
+					return fmt.Errorf("must be at least %s", attr)
+				}
+				return nil
+			}))
+		}
+	}
+	if attr := elem.GetAttr("max"); attr != "" {
+		if limit, err := strconv.ParseFloat(attr, 64); err == nil {
+			rules = append(rules, numberRule(func(n float64) error {
+				if n > limit {
+					return fmt.Errorf("must be at most %s", attr)
+				}
+				return nil
+			}))
+		}
+	}
+
+	for _, name := range strings.Split(elem.GetAttr("validate"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			rules = append(rules, b.namedValidator(name))
+		}
+	}
+
+	required := elem.GetAttr("required") == attrValueTrue
+	if len(rules) == 0 && !required {
+		return nil
+	}
+
+	validator := func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			if required {
+				return errors.New("is required")
+			}
+			return nil
+		}
+		return chainValidators(rules...)(value)
+	}
+
+	if msg := elem.GetAttr("errorMessage"); msg != "" {
+		return func(value string) error {
+			if validator(value) != nil {
+				return errors.New(msg)
+			}
+			return nil
+		}
+	}
+	return validator
+}
+
+// namedValidator restituisce un validatore che usa quello registrato con il
+// nome, risolto a ogni validazione
+func (b *Builder) namedValidator(name string) fyne.StringValidator {
+	return func(value string) error {
+		b.mu.RLock()
+		validator, ok := b.validators[name]
+		b.mu.RUnlock()
+		if !ok {
+			return fmt.Errorf("unknown validator %q", name)
+		}
+		return validator(value)
+	}
+}
+
+// numberRule applica una regola ai valori numerici
+func numberRule(rule func(float64) error) fyne.StringValidator {
+	return func(value string) error {
+		n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		return rule(n)
+	}
+}
+
+// chainValidators applica i validatori in ordine e restituisce il primo errore
+func chainValidators(validators ...fyne.StringValidator) fyne.StringValidator {
+	return func(value string) error {
+		for _, validator := range validators {
+			if err := validator(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
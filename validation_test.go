@@ -0,0 +1,136 @@
+package fylay
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestValidate(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<Entry id="name" required="true" minlength="3" maxlength="10" />
+		<Entry id="code" pattern="[A-Z]{3}" errorMessage="three capital letters" />
+		<Entry id="email" type="email" errorBind="emailError" />
+		<Entry id="age" type="number" min="18" max="99" />
+		<Entry id="site" type="url" />
+		<Entry id="user" validate="username" />
+		<Entry id="notes" />
+		<Select id="country" required="true">
+			<Option>IT</Option>
+			<Option>CH</Option>
+		</Select>
+		<Checkbox id="terms" required="true">Accept</Checkbox>
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	// Validators are resolved when validating
+	builder.RegisterValidator("username", func(value string) error {
+		if value == "root" {
+			return errors.New("reserved")
+		}
+		return nil
+	})
+
+	entry := func(id string) *widget.Entry {
+		return builder.GetWidget(id).(*widget.Entry)
+	}
+
+	// Empty optional fields are valid
+	errs := builder.Validate()
+	if len(errs) != 3 || errs["name"] == nil || errs["country"] == nil || errs["terms"] == nil {
+		t.Fatalf("Expected the required fields to fail, got %v", errs)
+	}
+	if errs["name"].Error() != "is required" {
+		t.Errorf("Unexpected error %v", errs["name"])
+	}
+	if entry("name").Validate() == nil {
+		t.Error("Expected the entry to carry the validator")
+	}
+
+	tests := []struct {
+		id, value, err string
+	}{
+		{"name", "Al", "must be at least 3 characters"},
+		{"name", "Bartholomew", "must be at most 10 characters"},
+		{"name", "Alice", ""},
+		{"code", "abc", "three capital letters"},
+		{"code", "ABC", ""},
+		{"email", "alice@", "must be a valid email address"},
+		{"email", "alice@example.com", ""},
+		{"age", "ten", "must be a number"},
+		{"age", "12", "must be at least 18"},
+		{"age", "120", "must be at most 99"},
+		{"age", "42", ""},
+		{"site", "example.com", "must be a valid URL"},
+		{"site", "https://example.com", ""},
+		{"user", "root", "reserved"},
+		{"user", "alice", ""},
+	}
+	for _, tt := range tests {
+		entry(tt.id).SetText(tt.value)
+		err := builder.Validate()[tt.id]
+		if tt.err == "" && err != nil || tt.err != "" && (err == nil || err.Error() != tt.err) {
+			t.Errorf("%s=%q: expected error %q, got %v", tt.id, tt.value, tt.err, err)
+		}
+	}
+
+	// The error message is bound while typing
+	entry("email").SetText("bob")
+	if msg, _ := builder.GetBindingContext().GetString("emailError"); msg != "must be a valid email address" {
+		t.Errorf("Unexpected bound error %q", msg)
+	}
+	entry("email").SetText("bob@example.com")
+	if msg, _ := builder.GetBindingContext().GetString("emailError"); msg != "" {
+		t.Errorf("Expected the bound error to clear, got %q", msg)
+	}
+
+	builder.GetWidget("country").(*widget.Select).SetSelected("CH")
+	builder.GetWidget("terms").(*widget.Check).SetChecked(true)
+	if errs := builder.Validate(); errs != nil {
+		t.Errorf("Expected a valid layout, got %v", errs)
+	}
+}
+
+func TestValidateRebuild(t *testing.T) {
+	_ = test.NewApp()
+
+	builder := NewBuilder()
+	load := func(xml string) *Layout {
+		layout, err := builder.LoadLayout(strings.NewReader(xml))
+		if err != nil {
+			t.Fatalf("Failed to load layout: %v", err)
+		}
+		return layout
+	}
+
+	if _, err := builder.Build(load(`<Layout><Entry id="name" required="true" /></Layout>`)); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+	if errs := builder.Validate(); errs["name"] == nil {
+		t.Fatal("Expected the required entry to fail")
+	}
+
+	// Fields of the replaced layout are no longer validated
+	if _, err := builder.Rebuild(load(`<Layout><Entry id="other" /></Layout>`)); err != nil {
+		t.Fatalf("Failed to rebuild: %v", err)
+	}
+	if errs := builder.Validate(); errs != nil {
+		t.Errorf("Expected no errors after rebuild, got %v", errs)
+	}
+}
@@ -0,0 +1,255 @@
+package fylay
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// interactionEvents lists the event attributes that need widget hooks
+// Fyne's base widgets don't expose as callbacks
+var interactionEvents = []string{
+	eventFocus,
+	eventBlur,
+	eventHover,
+	eventLeave,
+	eventDoubleTap,
+	eventSecondaryTap,
+	eventKey,
+}
+
+// hasInteractionEvents reports whether an element declares any interaction event
+func hasInteractionEvents(elem *Element) bool {
+	for _, attr := range interactionEvents {
+		if elem.GetAttr(attr) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// eventHooks dispatches the interaction events of a widget to the builder
+type eventHooks struct {
+	b      *Builder
+	elem   *Element
+	target fyne.CanvasObject
+}
+
+// newEventHooks returns the hooks for an element, or nil if it declares no
+// interaction events
+func (b *Builder) newEventHooks(elem *Element) *eventHooks {
+	if !hasInteractionEvents(elem) {
+		return nil
+	}
+	return &eventHooks{b: b, elem: elem}
+}
+
+func (h *eventHooks) fire(attr string, value any) {
+	h.b.fireEvent(attr, h.elem, h.target, value)
+}
+
+func (h *eventHooks) focusGained()                { h.fire(eventFocus, nil) }
+func (h *eventHooks) focusLost()                  { h.fire(eventBlur, nil) }
+func (h *eventHooks) mouseIn()                    { h.fire(eventHover, nil) }
+func (h *eventHooks) mouseOut()                   { h.fire(eventLeave, nil) }
+func (h *eventHooks) doubleTapped()               { h.fire(eventDoubleTap, nil) }
+func (h *eventHooks) tappedSecondary()            { h.fire(eventSecondaryTap, nil) }
+func (h *eventHooks) typedKey(key *fyne.KeyEvent) { h.fire(eventKey, string(key.Name)) }
+
+// The widgets below extend Fyne's interactive widgets with the interaction
+// hooks. They are only used when an element declares interaction events:
+// implementing fyne.DoubleTappable makes the driver wait for the double tap
+// delay before delivering a single tap.
+//
+// The builder registers the embedded Fyne widget, so GetWidget keeps
+// returning the usual *widget.Button, *widget.Entry, etc.
+
+type eventButton struct {
+	widget.Button
+	hooks *eventHooks
+}
+
+func (w *eventButton) FocusGained()                     { w.Button.FocusGained(); w.hooks.focusGained() }
+func (w *eventButton) FocusLost()                       { w.Button.FocusLost(); w.hooks.focusLost() }
+func (w *eventButton) MouseIn(ev *desktop.MouseEvent)   { w.Button.MouseIn(ev); w.hooks.mouseIn() }
+func (w *eventButton) MouseOut()                        { w.Button.MouseOut(); w.hooks.mouseOut() }
+func (w *eventButton) DoubleTapped(*fyne.PointEvent)    { w.hooks.doubleTapped() }
+func (w *eventButton) TappedSecondary(*fyne.PointEvent) { w.hooks.tappedSecondary() }
+func (w *eventButton) TypedKey(key *fyne.KeyEvent)      { w.Button.TypedKey(key); w.hooks.typedKey(key) }
+
+type eventEntry struct {
+	widget.Entry
+	hooks *eventHooks
+}
+
+func (w *eventEntry) FocusGained()                   { w.Entry.FocusGained(); w.hooks.focusGained() }
+func (w *eventEntry) FocusLost()                     { w.Entry.FocusLost(); w.hooks.focusLost() }
+func (w *eventEntry) MouseIn(*desktop.MouseEvent)    { w.hooks.mouseIn() }
+func (w *eventEntry) MouseMoved(*desktop.MouseEvent) {}
+func (w *eventEntry) MouseOut()                      { w.hooks.mouseOut() }
+func (w *eventEntry) DoubleTapped(ev *fyne.PointEvent) {
+	w.Entry.DoubleTapped(ev)
+	w.hooks.doubleTapped()
+}
+func (w *eventEntry) TappedSecondary(ev *fyne.PointEvent) {
+	w.Entry.TappedSecondary(ev)
+	w.hooks.tappedSecondary()
+}
+func (w *eventEntry) TypedKey(key *fyne.KeyEvent) { w.Entry.TypedKey(key); w.hooks.typedKey(key) }
+
+type eventCheck struct {
+	widget.Check
+	hooks *eventHooks
+}
+
+func (w *eventCheck) FocusGained()                     { w.Check.FocusGained(); w.hooks.focusGained() }
+func (w *eventCheck) FocusLost()                       { w.Check.FocusLost(); w.hooks.focusLost() }
+func (w *eventCheck) MouseIn(ev *desktop.MouseEvent)   { w.Check.MouseIn(ev); w.hooks.mouseIn() }
+func (w *eventCheck) MouseOut()                        { w.Check.MouseOut(); w.hooks.mouseOut() }
+func (w *eventCheck) DoubleTapped(*fyne.PointEvent)    { w.hooks.doubleTapped() }
+func (w *eventCheck) TappedSecondary(*fyne.PointEvent) { w.hooks.tappedSecondary() }
+func (w *eventCheck) TypedKey(key *fyne.KeyEvent)      { w.Check.TypedKey(key); w.hooks.typedKey(key) }
+
+type eventSelect struct {
+	widget.Select
+	hooks *eventHooks
+}
+
+func (w *eventSelect) FocusGained()                     { w.Select.FocusGained(); w.hooks.focusGained() }
+func (w *eventSelect) FocusLost()                       { w.Select.FocusLost(); w.hooks.focusLost() }
+func (w *eventSelect) MouseIn(ev *desktop.MouseEvent)   { w.Select.MouseIn(ev); w.hooks.mouseIn() }
+func (w *eventSelect) MouseOut()                        { w.Select.MouseOut(); w.hooks.mouseOut() }
+func (w *eventSelect) DoubleTapped(*fyne.PointEvent)    { w.hooks.doubleTapped() }
+func (w *eventSelect) TappedSecondary(*fyne.PointEvent) { w.hooks.tappedSecondary() }
+func (w *eventSelect) TypedKey(key *fyne.KeyEvent)      { w.Select.TypedKey(key); w.hooks.typedKey(key) }
+
+type eventSlider struct {
+	widget.Slider
+	hooks *eventHooks
+}
+
+func (w *eventSlider) FocusGained()                     { w.Slider.FocusGained(); w.hooks.focusGained() }
+func (w *eventSlider) FocusLost()                       { w.Slider.FocusLost(); w.hooks.focusLost() }
+func (w *eventSlider) MouseIn(ev *desktop.MouseEvent)   { w.Slider.MouseIn(ev); w.hooks.mouseIn() }
+func (w *eventSlider) MouseOut()                        { w.Slider.MouseOut(); w.hooks.mouseOut() }
+func (w *eventSlider) DoubleTapped(*fyne.PointEvent)    { w.hooks.doubleTapped() }
+func (w *eventSlider) TappedSecondary(*fyne.PointEvent) { w.hooks.tappedSecondary() }
+func (w *eventSlider) TypedKey(key *fyne.KeyEvent)      { w.Slider.TypedKey(key); w.hooks.typedKey(key) }
+
+// newButton creates the button for an element. obj is the object to place
+// in the layout, btn the Fyne widget to configure and register.
+func (b *Builder) newButton(elem *Element) (obj fyne.CanvasObject, btn *widget.Button) {
+	if hooks := b.newEventHooks(elem); hooks != nil {
+		w := &eventButton{hooks: hooks}
+		w.ExtendBaseWidget(w)
+		hooks.target = &w.Button
+		return w, &w.Button
+	}
+	btn = &widget.Button{}
+	btn.ExtendBaseWidget(btn)
+	return btn, btn
+}
+
+// newEntry creates the entry for an element, see newButton
+func (b *Builder) newEntry(elem *Element) (obj fyne.CanvasObject, entry *widget.Entry) {
+	if hooks := b.newEventHooks(elem); hooks != nil {
+		w := &eventEntry{hooks: hooks}
+		w.Wrapping = fyne.TextWrap(fyne.TextTruncateClip)
+		w.ExtendBaseWidget(w)
+		hooks.target = &w.Entry
+		return w, &w.Entry
+	}
+	entry = widget.NewEntry()
+	return entry, entry
+}
+
+// newCheck creates the checkbox for an element, see newButton
+func (b *Builder) newCheck(elem *Element) (obj fyne.CanvasObject, check *widget.Check) {
+	if hooks := b.newEventHooks(elem); hooks != nil {
+		w := &eventCheck{hooks: hooks}
+		w.ExtendBaseWidget(w)
+		hooks.target = &w.Check
+		return w, &w.Check
+	}
+	check = widget.NewCheck("", nil)
+	return check, check
+}
+
+// newSelect creates the select for an element, see newButton
+func (b *Builder) newSelect(elem *Element) (obj fyne.CanvasObject, sel *widget.Select) {
+	if hooks := b.newEventHooks(elem); hooks != nil {
+		w := &eventSelect{hooks: hooks}
+		w.ExtendBaseWidget(w)
+		hooks.target = &w.Select
+		return w, &w.Select
+	}
+	sel = widget.NewSelect(nil, nil)
+	return sel, sel
+}
+
+// newSlider creates the slider for an element, see newButton
+func (b *Builder) newSlider(elem *Element, min, max float64) (obj fyne.CanvasObject, slider *widget.Slider) {
+	if hooks := b.newEventHooks(elem); hooks != nil {
+		w := &eventSlider{hooks: hooks}
+		w.Min, w.Max, w.Step = min, max, 1
+		w.Orientation = widget.Horizontal
+		w.ExtendBaseWidget(w)
+		hooks.target = &w.Slider
+		return w, &w.Slider
+	}
+	slider = widget.NewSlider(min, max)
+	return slider, slider
+}
+
+// eventArea wraps elements without interaction support of their own (labels,
+// shapes, images, containers) so they can receive interaction events.
+// Interactive children inside the area still take precedence for the pointer
+// events they handle themselves.
+type eventArea struct {
+	widget.BaseWidget
+	content fyne.CanvasObject
+	hooks   *eventHooks
+}
+
+// newEventArea wraps an object in an eventArea
+func newEventArea(hooks *eventHooks, content fyne.CanvasObject) *eventArea {
+	area := &eventArea{content: content, hooks: hooks}
+	area.ExtendBaseWidget(area)
+	return area
+}
+
+// CreateRenderer implements fyne.Widget
+func (a *eventArea) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(a.content)
+}
+
+// Tapped requests the focus, so the area can receive key events
+func (a *eventArea) Tapped(*fyne.PointEvent) {
+	if c := fyne.CurrentApp().Driver().CanvasForObject(a); c != nil {
+		c.Focus(a)
+	}
+}
+
+func (a *eventArea) FocusGained()                     { a.hooks.focusGained() }
+func (a *eventArea) FocusLost()                       { a.hooks.focusLost() }
+func (a *eventArea) TypedRune(rune)                   {}
+func (a *eventArea) TypedKey(key *fyne.KeyEvent)      { a.hooks.typedKey(key) }
+func (a *eventArea) MouseIn(*desktop.MouseEvent)      { a.hooks.mouseIn() }
+func (a *eventArea) MouseMoved(*desktop.MouseEvent)   {}
+func (a *eventArea) MouseOut()                        { a.hooks.mouseOut() }
+func (a *eventArea) DoubleTapped(*fyne.PointEvent)    { a.hooks.doubleTapped() }
+func (a *eventArea) TappedSecondary(*fyne.PointEvent) { a.hooks.tappedSecondary() }
+
+// wrapInteractionEvents wraps the object built for an element in an eventArea
+// when the element declares interaction events
+func (b *Builder) wrapInteractionEvents(elem *Element, obj fyne.CanvasObject) fyne.CanvasObject {
+	hooks := b.newEventHooks(elem)
+	if hooks == nil || obj == nil {
+		return obj
+	}
+
+	hooks.target = obj
+	area := newEventArea(hooks, obj)
+	b.RegisterElement(elem.ID, area)
+	return area
+}
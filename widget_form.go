@@ -0,0 +1,355 @@
+package fylay
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/sandrolain/fylay/core"
+)
+
+// formValues sono i valori dei campi di un Form inviato, per nome del campo
+type formValues map[string]any
+
+// formField è un campo con nome di un Form
+type formField struct {
+	name string
+	id   string
+}
+
+// formState è un Form costruito dal layout, con i suoi campi
+type formState struct {
+	form        *widget.Form
+	fields      []formField
+	validations []*fieldValidation // Campi con regole di validazione
+}
+
+// buildForm costruisce un Form con una riga per ogni figlio <FormItem>:
+//
+//	<Form id="signup" onsubmit="register" oncancel="close" submitText="Sign up">
+//		<FormItem label="Email" hint="We never share it">
+//			<Entry id="email" type="email" required="true" />
+//		</FormItem>
+//		<FormItem label="Newsletter">
+//			<Checkbox id="news" name="newsletter" />
+//		</FormItem>
+//	</Form>
+//
+// I campi con ID dentro le righe sono raccolti con il loro attributo name, o
+// con l'ID. Il pulsante di invio compare con onsubmit ed è disabilitato
+// finché un campo con regole di validazione non è valido; onsubmit riceve i
+// valori dei campi in EventContext.Values. Il pulsante di annullamento
+// compare con oncancel. I valori si leggono in ogni momento con FormValues.
+func (b *Builder) buildForm(elem Element, style map[string]string) fyne.CanvasObject {
+	state := &formState{}
+
+	var items []*widget.FormItem
+	for _, child := range elem.Children {
+		label, hint := child.GetAttr("label"), child.GetAttr("hint")
+		content := []Element{child}
+		if child.XMLName.Local == "FormItem" {
+			content = child.Children
+		}
+
+		var obj fyne.CanvasObject
+		switch objects := b.BuildChildren(Element{Children: content}); len(objects) {
+		case 0:
+			continue
+		case 1:
+			obj = objects[0]
+		default:
+			obj = container.NewVBox(objects...)
+		}
+
+		// Le righe con regole di validazione le segnalano al form
+		var fields []*fieldValidation
+		for _, e := range content {
+			state.fields = append(state.fields, b.collectFields(e, &fields)...)
+		}
+		if len(fields) > 0 {
+			obj = newValidatedItem(obj, fields)
+			state.validations = append(state.validations, fields...)
+		}
+
+		items = append(items, &widget.FormItem{Text: label, Widget: obj, HintText: hint})
+	}
+
+	// Le righe sono passate alla creazione: aggiungerle dopo disegna il form
+	// prima di configurarne i pulsanti
+	form := widget.NewForm(items...)
+	state.form = form
+
+	if text := elem.GetAttr("submitText"); text != "" {
+		form.SubmitText = text
+	}
+	if text := elem.GetAttr("cancelText"); text != "" {
+		form.CancelText = text
+	}
+
+	if elem.GetAttr(eventSubmit) != "" {
+		form.OnSubmit = func() {
+			// I campi non validi bloccano l'invio e mostrano i loro errori
+			if !state.validate() {
+				return
+			}
+			b.fireEvent(eventSubmit, &elem, form, formValues(b.formFieldValues(state)))
+		}
+	}
+	if elem.GetAttr(eventCancel) != "" {
+		form.OnCancel = func() {
+			b.fireEvent(eventCancel, &elem, form, nil)
+		}
+	}
+
+	// Registra il widget con ID prima di applicare gli stili
+	if elem.ID != "" {
+		b.GetBindingContext().RegisterWidget(elem.ID, form)
+		b.RegisterWidget(elem.ID, form)
+		b.buildingScope().addForm(elem.ID, state)
+	}
+
+	styled := core.ApplyMinSize(form, style)
+	b.RegisterElement(elem.ID, styled)
+
+	return styled
+}
+
+// collectFields restituisce i campi con ID di un elemento e dei suoi figli,
+// aggiungendo le loro validazioni a validations
+func (b *Builder) collectFields(elem Element, validations *[]*fieldValidation) []formField {
+	var fields []formField
+	if elem.ID != "" {
+		name := elem.GetAttr("name")
+		if name == "" {
+			name = elem.ID
+		}
+		fields = append(fields, formField{name: name, id: elem.ID})
+
+		if field, ok := b.buildingScope().field(elem.ID); ok {
+			*validations = append(*validations, field)
+		}
+	}
+
+	for _, child := range elem.Children {
+		fields = append(fields, b.collectFields(child, validations)...)
+	}
+	return fields
+}
+
+// validate valida i campi del form, mostrando gli errori; restituisce false
+// se un campo non è valido
+func (s *formState) validate() bool {
+	valid := true
+	for _, field := range s.validations {
+		if field.check(true) != nil {
+			valid = false
+		}
+	}
+	return valid
+}
+
+// FormValues decodifica i valori correnti dei campi del Form con l'ID dato in
+// dst, un puntatore a map[string]any o a una struct. I campi della struct
+// corrispondono al tag fylay o, senza distinguere maiuscole e minuscole, al
+// nome; il testo viene convertito in numeri e booleani dove serve.
+func (b *Builder) FormValues(id string, dst any) error {
+	state, ok := b.currentScope().form(id)
+	if !ok {
+		return fmt.Errorf("form %q not found", id)
+	}
+	values := b.formFieldValues(state)
+
+	switch dst := dst.(type) {
+	case *map[string]any:
+		if dst == nil {
+			return errors.New("form values destination is nil")
+		}
+		*dst = values
+		return nil
+	default:
+		return decodeStruct(values, dst)
+	}
+}
+
+// formFieldValues restituisce i valori dei campi di un form, tralasciando i
+// widget senza valore come le label
+func (b *Builder) formFieldValues(state *formState) map[string]any {
+	values := make(map[string]any, len(state.fields))
+	for _, f := range state.fields {
+		if value, ok := widgetValue(b.GetWidget(f.id)); ok {
+			values[f.name] = value
+		}
+	}
+	return values
+}
+
+// widgetValue restituisce il valore di un widget di input
+func widgetValue(w fyne.CanvasObject) (any, bool) {
+	switch w := w.(type) {
+	case *widget.Entry:
+		return w.Text, true
+	case *widget.Check:
+		return w.Checked, true
+	case *widget.Select:
+		return w.Selected, true
+	case *widget.RadioGroup:
+		return w.Selected, true
+	case *widget.CheckGroup:
+		return append([]string(nil), w.Selected...), true
+	case *widget.Slider:
+		return w.Value, true
+	default:
+		return nil, false
+	}
+}
+
+// decodeStruct imposta i campi di un puntatore a struct da una mappa di valori
+func decodeStruct(values map[string]any, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("form values destination must be a pointer to a struct or map, got %T", dst)
+	}
+	v = v.Elem()
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, tagged := field.Tag.Lookup(controllerTag)
+		if !tagged {
+			name = field.Name
+		}
+		value, ok := formValue(values, name, !tagged)
+		if !ok {
+			continue
+		}
+
+		decoded, err := decodeValue(value, field.Type)
+		if err != nil {
+			return fmt.Errorf("form field %s: %w", name, err)
+		}
+		v.Field(i).Set(decoded)
+	}
+	return nil
+}
+
+// formValue cerca un valore per nome, con fold senza distinguere maiuscole e
+// minuscole
+func formValue(values map[string]any, name string, fold bool) (any, bool) {
+	if value, ok := values[name]; ok {
+		return value, true
+	}
+	if fold {
+		for key, value := range values {
+			if strings.EqualFold(key, name) {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// decodeValue converte il valore di un campo in un tipo, interpretando il
+// testo per numeri e booleani. Il testo vuoto è il valore zero.
+func decodeValue(value any, t reflect.Type) (reflect.Value, error) {
+	if v, err := assignable(value, t); err == nil {
+		return v, nil
+	}
+	if text, ok := value.(string); ok && strings.TrimSpace(text) == "" {
+		return reflect.Zero(t), nil
+	}
+
+	var converted any
+	var err error
+	switch t.Kind() {
+	case reflect.String:
+		converted = formatValue(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		converted, err = toInt(value)
+	case reflect.Float32, reflect.Float64:
+		converted, err = toFloat(value)
+	case reflect.Bool:
+		converted, err = toBool(value)
+	default:
+		return reflect.Value{}, fmt.Errorf("cannot assign %T to %s", value, t)
+	}
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(converted).Convert(t), nil
+}
+
+// validatedItem è il contenuto di una riga del form con regole di
+// validazione: permette al form di disabilitare il pulsante di invio e di
+// mostrare l'errore sotto la riga.
+type validatedItem struct {
+	widget.BaseWidget
+	content fyne.CanvasObject
+	fields  []*fieldValidation
+
+	onValidationChanged func(error) // Impostata dal form, chiamata sul thread Fyne
+	lastErr             error
+}
+
+var _ fyne.Validatable = (*validatedItem)(nil)
+
+// newValidatedItem avvolge il contenuto di una riga del form
+func newValidatedItem(content fyne.CanvasObject, fields []*fieldValidation) *validatedItem {
+	item := &validatedItem{content: content, fields: fields}
+	item.ExtendBaseWidget(item)
+	for _, field := range fields {
+		field.listeners = append(field.listeners, item.changed)
+	}
+	return item
+}
+
+// CreateRenderer mostra il contenuto della riga
+func (i *validatedItem) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(i.content)
+}
+
+// Validate restituisce il primo errore dei campi della riga, senza mostrarlo
+// sui campi
+func (i *validatedItem) Validate() error {
+	for _, field := range i.fields {
+		if err := field.valid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetOnValidationChanged è usata dal form per seguire la validazione, dopo
+// aver validato lo stato iniziale della riga
+func (i *validatedItem) SetOnValidationChanged(callback func(error)) {
+	i.onValidationChanged = callback
+	i.lastErr = i.Validate()
+}
+
+// changed avvisa il form quando cambia il risultato della validazione
+func (i *validatedItem) changed() {
+	err := i.Validate()
+	if sameError(err, i.lastErr) {
+		return
+	}
+	i.lastErr = err
+	if i.onValidationChanged != nil {
+		i.onValidationChanged(err)
+	}
+}
+
+// sameError indica se due errori hanno lo stesso messaggio
+func sameError(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Error() == b.Error()
+}
@@ -0,0 +1,169 @@
+package fylay
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestFormSubmit(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<Form id="signup" onsubmit="register" oncancel="close" submitText="Sign up">
+		<FormItem label="Email" hint="We never share it">
+			<Entry id="email" type="email" required="true" />
+		</FormItem>
+		<FormItem label="Age">
+			<Entry id="age" type="number" />
+		</FormItem>
+		<FormItem label="Plan">
+			<Select id="plan" required="true">
+				<Option>free</Option>
+				<Option>pro</Option>
+			</Select>
+		</FormItem>
+		<FormItem label="Newsletter">
+			<Checkbox id="news" name="newsletter" />
+		</FormItem>
+	</Form>
+</Layout>
+`
+	builder := NewBuilder()
+
+	var submitted *EventContext
+	cancelled := false
+	builder.On("register", func(ev *EventContext) { submitted = ev })
+	builder.On("close", func(*EventContext) { cancelled = true })
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	form := builder.GetWidget("signup").(*widget.Form)
+	w := test.NewWindow(form)
+	defer w.Close()
+	w.Resize(fyne.NewSize(400, 400))
+
+	submit := func() *widget.Button {
+		for _, o := range visibleObjects(form) {
+			if btn, ok := o.(*widget.Button); ok && btn.Text == "Sign up" {
+				return btn
+			}
+		}
+		t.Fatal("Submit button not found")
+		return nil
+	}
+	if len(form.Items) != 4 || form.Items[0].HintText != "We never share it" {
+		t.Fatalf("Unexpected form items %+v", form.Items)
+	}
+	if !submit().Disabled() {
+		t.Error("Expected submit to be disabled while required fields are empty")
+	}
+
+	// Invalid fields block the submit
+	form.OnSubmit()
+	if submitted != nil {
+		t.Fatal("Expected invalid form not to submit")
+	}
+
+	builder.GetWidget("email").(*widget.Entry).SetText("alice@example.com")
+	builder.GetWidget("age").(*widget.Entry).SetText("42")
+	builder.GetWidget("plan").(*widget.Select).SetSelected("pro")
+	builder.GetWidget("news").(*widget.Check).SetChecked(true)
+	if submit().Disabled() {
+		t.Error("Expected submit to be enabled once the fields are valid")
+	}
+
+	test.Tap(submit())
+	if submitted == nil {
+		t.Fatal("Expected onsubmit to fire")
+	}
+	want := map[string]any{"email": "alice@example.com", "age": "42", "plan": "pro", "newsletter": true}
+	for name, value := range want {
+		if submitted.Values[name] != value {
+			t.Errorf("Expected %s=%v, got %v", name, value, submitted.Values[name])
+		}
+	}
+
+	form.OnCancel()
+	if !cancelled {
+		t.Error("Expected oncancel to fire")
+	}
+
+	// Values decode into structs, converting text as needed
+	var signup struct {
+		Email string
+		Age   int
+		Plan  string
+		News  bool `fylay:"newsletter"`
+	}
+	if err := builder.FormValues("signup", &signup); err != nil {
+		t.Fatalf("FormValues failed: %v", err)
+	}
+	if signup.Email != "alice@example.com" || signup.Age != 42 || signup.Plan != "pro" || !signup.News {
+		t.Errorf("Unexpected decoded values %+v", signup)
+	}
+
+	var values map[string]any
+	if err := builder.FormValues("signup", &values); err != nil || values["plan"] != "pro" {
+		t.Errorf("Expected values in a map, got %v, %v", values, err)
+	}
+	if err := builder.FormValues("missing", &values); err == nil {
+		t.Error("Expected an error for an unknown form")
+	}
+}
+
+func TestFormRebuild(t *testing.T) {
+	_ = test.NewApp()
+
+	builder := NewBuilder()
+	load := func(xml string) *Layout {
+		layout, err := builder.LoadLayout(strings.NewReader(xml))
+		if err != nil {
+			t.Fatalf("Failed to load layout: %v", err)
+		}
+		return layout
+	}
+
+	submitted := false
+	builder.On("send", func(*EventContext) { submitted = true })
+
+	// Fields outside the form do not block its submit
+	layout := load(`
+<Layout>
+	<VBox>
+		<Entry id="search" required="true" />
+		<Form id="note" onsubmit="send">
+			<FormItem label="Note">
+				<Entry id="text" />
+			</FormItem>
+		</Form>
+	</VBox>
+</Layout>
+`)
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+	builder.GetWidget("note").(*widget.Form).OnSubmit()
+	if !submitted {
+		t.Error("Expected a form without validation rules to submit")
+	}
+
+	// Forms of the replaced layout are no longer known
+	if _, err := builder.Rebuild(load(`<Layout><Entry id="other" /></Layout>`)); err != nil {
+		t.Fatalf("Failed to rebuild: %v", err)
+	}
+	var values map[string]any
+	if err := builder.FormValues("note", &values); err == nil {
+		t.Errorf("Expected the replaced form to be unknown, got %v", values)
+	}
+}
@@ -1,7 +1,6 @@
 package fylay
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"github.com/coocood/freecache"
 )
@@ -31,10 +31,7 @@ type ImageWidget struct {
 
 // NewImageWidget creates a new image widget
 func NewImageWidget(src string) (*ImageWidget, error) {
-	img := &ImageWidget{
-		Image: canvas.NewImageFromFile(""),
-		src:   src,
-	}
+	img := newImageWidget(src)
 
 	if err := img.Load(); err != nil {
 		return nil, err
@@ -43,10 +40,23 @@ func NewImageWidget(src string) (*ImageWidget, error) {
 	return img, nil
 }
 
+// newImageWidget creates an image widget without loading its source
+func newImageWidget(src string) *ImageWidget {
+	return &ImageWidget{
+		Image: canvas.NewImageFromFile(""),
+		src:   src,
+	}
+}
+
+// isURL reports whether the source is an HTTP(S) URL
+func (iw *ImageWidget) isURL() bool {
+	return strings.HasPrefix(iw.src, "http://") || strings.HasPrefix(iw.src, "https://")
+}
+
 // Load loads the image from source (file or URL)
 func (iw *ImageWidget) Load() error {
 	// Check if it's a URL
-	if strings.HasPrefix(iw.src, "http://") || strings.HasPrefix(iw.src, "https://") {
+	if iw.isURL() {
 		return iw.loadFromURL()
 	}
 
@@ -54,6 +64,42 @@ func (iw *ImageWidget) Load() error {
 	return iw.loadFromFile()
 }
 
+// LoadAsync loads the image without blocking the caller.
+// URLs are downloaded on a background goroutine and the image is updated
+// on the Fyne main thread; done, if not nil, is called there as well.
+// Files and cached URLs are loaded immediately.
+func (iw *ImageWidget) LoadAsync(done func(error)) {
+	if !iw.isURL() {
+		err := iw.loadFromFile()
+		if done != nil {
+			done(err)
+		}
+		return
+	}
+
+	src := iw.src
+	if data, ok := cachedImage(src); ok {
+		iw.setImageData(data)
+		if done != nil {
+			done(nil)
+		}
+		return
+	}
+
+	go func() {
+		data, err := fetchImage(src)
+		fyne.Do(func() {
+			// Ignore stale downloads if the source changed meanwhile
+			if err == nil && iw.src == src {
+				iw.setImageData(data)
+			}
+			if done != nil {
+				done(err)
+			}
+		})
+	}()
+}
+
 // loadFromFile loads image from local file
 func (iw *ImageWidget) loadFromFile() error {
 	absPath, err := filepath.Abs(iw.src)
@@ -73,42 +119,63 @@ func (iw *ImageWidget) loadFromFile() error {
 
 // loadFromURL loads image from HTTP(S) URL with caching
 func (iw *ImageWidget) loadFromURL() error {
+	data, err := fetchImage(iw.src)
+	if err != nil {
+		return err
+	}
+
+	iw.setImageData(data)
+
+	return nil
+}
+
+// setImageData displays downloaded image data, keeping the same canvas.Image
+// so containers already holding the widget see the update
+func (iw *ImageWidget) setImageData(data []byte) {
+	iw.File = ""
+	iw.Resource = fyne.NewStaticResource(filepath.Base(iw.src), data)
+	iw.Refresh()
+}
+
+// cachedImage returns the cached data for an image URL
+func cachedImage(src string) ([]byte, bool) {
+	cached, err := imageCache.Get([]byte(src))
+	if err != nil {
+		return nil, false
+	}
+	return cached, true
+}
+
+// fetchImage returns the data for an image URL, downloading it on cache miss
+func fetchImage(src string) ([]byte, error) {
 	// Try to get from cache first
-	cacheKey := []byte(iw.src)
-	if cached, err := imageCache.Get(cacheKey); err == nil {
-		// Cache hit
-		img := canvas.NewImageFromReader(bytes.NewReader(cached), filepath.Base(iw.src))
-		iw.Image = img
-		return nil
+	if cached, ok := cachedImage(src); ok {
+		return cached, nil
 	}
 
 	// Cache miss - download from URL
-	resp, err := httpClient.Get(iw.src)
+	resp, err := httpClient.Get(src)
 	if err != nil {
-		return fmt.Errorf("failed to download image: %w", err)
+		return nil, fmt.Errorf("failed to download image: %w", err)
 	}
 	defer func() {
 		_ = resp.Body.Close() //nolint:errcheck // Defer close error can be ignored
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download image: status %d", resp.StatusCode)
+		return nil, fmt.Errorf("failed to download image: status %d", resp.StatusCode)
 	}
 
 	// Read response body
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read image data: %w", err)
+		return nil, fmt.Errorf("failed to read image data: %w", err)
 	}
 
 	// Store in cache (1 hour TTL)
-	_ = imageCache.Set(cacheKey, data, 3600) //nolint:errcheck // Cache error can be ignored
+	_ = imageCache.Set([]byte(src), data, 3600) //nolint:errcheck // Cache error can be ignored
 
-	// Create image from data
-	img := canvas.NewImageFromReader(bytes.NewReader(data), filepath.Base(iw.src))
-	iw.Image = img
-
-	return nil
+	return data, nil
 }
 
 // SetSource updates the image source and reloads
@@ -117,6 +184,16 @@ func (iw *ImageWidget) SetSource(src string) error {
 	return iw.Load()
 }
 
+// setSourceAsync updates the image source and reloads it with LoadAsync,
+// ignoring load errors
+func (iw *ImageWidget) setSourceAsync(src string) {
+	if src == iw.src && (iw.File != "" || iw.Resource != nil) {
+		return
+	}
+	iw.src = src
+	iw.LoadAsync(nil)
+}
+
 // GetSource returns the current image source
 func (iw *ImageWidget) GetSource() string {
 	return iw.src
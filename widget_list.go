@@ -0,0 +1,448 @@
+package fylay
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/sandrolain/fylay/core"
+)
+
+// itemRoot is the name rows use to refer to their item in a template,
+// e.g. bind="item.Name" or ${item.price}
+const itemRoot = "item"
+
+// rowCount numbers the row scopes registered in binding contexts
+var rowCount atomic.Uint64
+
+// buildList builds a virtualized List of the items of a bound list:
+//
+//	<List bind="todos" onselect="openTodo">
+//		<Label>${item.Title}</Label>
+//	</List>
+//
+// The child element is the row template, where item refers to the row's
+// item; without children each row is a Label showing the item. IDs inside the
+// template are ignored, since every row repeats them. onselect receives the
+// index as value and the item in EventContext.Item.
+func (b *Builder) buildList(elem Element, style map[string]string) fyne.CanvasObject {
+	ctx := b.GetBindingContext()
+
+	var listData binding.DataList
+	if key := ParseBindAttribute(elem.GetAttr("bind")); key != "" {
+		if data, ok := ctx.GetBinding(key); ok {
+			// Bindings that are not lists are ignored
+			listData, _ = data.(binding.DataList) //nolint:errcheck // Checked below
+		} else {
+			listData = ctx.BindList(key, nil)
+		}
+	}
+	if listData == nil {
+		listData = binding.NewUntypedList()
+	}
+
+	template := itemTemplate(elem)
+	scope := b.buildingScope()
+
+	// Rows are created and reused by the list on the Fyne thread
+	var mu sync.Mutex
+	rows := make(map[fyne.CanvasObject]*listRow)
+
+	list := widget.NewListWithData(listData,
+		func() fyne.CanvasObject {
+			row := &listRow{}
+			obj := b.buildRow(scope, template, row)
+
+			mu.Lock()
+			rows[obj] = row
+			mu.Unlock()
+			return obj
+		},
+		func(item binding.DataItem, obj fyne.CanvasObject) {
+			mu.Lock()
+			row := rows[obj]
+			mu.Unlock()
+			if row != nil {
+				row.setItem(item)
+			}
+		})
+
+	if elem.GetAttr(eventSelection) != "" {
+		list.OnSelected = func(id widget.ListItemID) {
+			var value any
+			if item, err := listData.GetItem(id); err == nil {
+				value, _ = dataValue(item) //nolint:errcheck // Unreadable items are nil
+			}
+			b.fireEvent(eventSelection, &elem, list, selectionValue{id: id, item: value})
+		}
+	}
+
+	// Register widget with ID before applying styles
+	if elem.ID != "" {
+		ctx.RegisterWidget(elem.ID, list)
+		b.RegisterWidget(elem.ID, list)
+	}
+
+	styled := core.ApplyMinSize(list, style)
+	b.RegisterElement(elem.ID, styled)
+
+	return styled
+}
+
+// itemTemplate returns the row template of a list element. Several children
+// are stacked in a VBox; without children the row shows the item in a Label.
+func itemTemplate(elem Element) Element {
+	switch len(elem.Children) {
+	case 0:
+		return Element{XMLName: xml.Name{Local: "Label"}, Content: "${" + itemRoot + "}"}
+	case 1:
+		return elem.Children[0]
+	default:
+		return Element{XMLName: xml.Name{Local: "VBox"}, Children: elem.Children}
+	}
+}
+
+// buildRow builds a row from the template, with item renamed to the key of
+// the row scope. The row scope is removed when scope, the one of the built
+// tree holding the list, is released.
+func (b *Builder) buildRow(scope *buildScope, template Element, row *listRow) fyne.CanvasObject {
+	ctx := b.GetBindingContext()
+	rowKey := ctx.registerRow(row)
+	scope.onRelease(func() {
+		ctx.unregisterRow(rowKey)
+	})
+
+	obj, err := b.BuildElement(renameItem(template, rowKey))
+	if err != nil || obj == nil {
+		return widget.NewLabel("")
+	}
+	return obj
+}
+
+// renameItem returns a copy of a template element where references to item
+// in bindings, placeholders and handler arguments use rowKey instead
+func renameItem(elem Element, rowKey string) Element {
+	elem.ID = ""
+	elem.Text = renamePlaceholders(elem.Text, rowKey)
+	elem.Content = renamePlaceholders(elem.Content, rowKey)
+
+	attrs := make([]xml.Attr, len(elem.Attributes))
+	copy(attrs, elem.Attributes)
+	for i, attr := range attrs {
+		switch {
+		case attr.Name.Local == "bind" || isEventAttribute(attr.Name.Local):
+			attrs[i].Value = renameRoot(attr.Value, itemRoot, rowKey)
+		default:
+			attrs[i].Value = renamePlaceholders(attr.Value, rowKey)
+		}
+	}
+	elem.Attributes = attrs
+
+	children := make([]Element, len(elem.Children))
+	for i, child := range elem.Children {
+		children[i] = renameItem(child, rowKey)
+	}
+	elem.Children = children
+
+	return elem
+}
+
+// isEventAttribute reports whether an attribute is an event attribute
+func isEventAttribute(name string) bool {
+	for _, attr := range eventAttributes {
+		if attr == name {
+			return true
+		}
+	}
+	return false
+}
+
+// renamePlaceholders renames item inside the ${...} placeholders of a text
+func renamePlaceholders(text, rowKey string) string {
+	if !hasInterpolation(text) {
+		return text
+	}
+
+	var sb strings.Builder
+	for {
+		start := strings.Index(text, "${")
+		if start < 0 {
+			break
+		}
+		end := placeholderEnd(text, start+2)
+		if end < 0 {
+			break
+		}
+		sb.WriteString(text[:start+2])
+		sb.WriteString(renameRoot(text[start+2:end], itemRoot, rowKey))
+		sb.WriteByte('}')
+		text = text[end+1:]
+	}
+	sb.WriteString(text)
+
+	return sb.String()
+}
+
+// renameRoot renames the identifiers from, or paths starting with from.,
+// outside quoted strings
+func renameRoot(src, from, to string) string {
+	var sb strings.Builder
+	var quote byte
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && i+1 < len(src) {
+				sb.WriteByte(c)
+				i++
+				c = src[i]
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case isIdentStart(c) && (i == 0 || !isIdentPart(src[i-1])):
+			j := i
+			for j < len(src) && isIdentPart(src[j]) {
+				j++
+			}
+			ident := src[i:j]
+			if ident == from || strings.HasPrefix(ident, from+".") {
+				ident = to + ident[len(from):]
+			}
+			sb.WriteString(ident)
+			i = j - 1
+			continue
+		}
+		sb.WriteByte(c)
+	}
+
+	return sb.String()
+}
+
+// isIdentStart reports whether c can start an identifier
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// isIdentPart reports whether c can be part of an identifier or path
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9' || c == '.'
+}
+
+// registerRow binds a row scope to a new key and returns the key. Row
+// scopes are kept apart from the bound keys, so they are not part of the
+// state of the context.
+func (bc *BindingContext) registerRow(row *listRow) string {
+	key := fmt.Sprintf("_row%d", rowCount.Add(1))
+
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.rows[key] = row
+	return key
+}
+
+// unregisterRow removes the row scope bound to a key
+func (bc *BindingContext) unregisterRow(key string) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	delete(bc.rows, key)
+}
+
+// listRow holds the item shown by a list or tree row. Its listeners are notified when
+// the row is reused for another item or the item is changed from the row.
+type listRow struct {
+	mu     sync.RWMutex
+	source binding.DataItem // Item binding of the list
+	value  any
+
+	listeners []binding.DataListener // Accessed on the Fyne thread only
+}
+
+// setItem shows another item in the row
+func (r *listRow) setItem(item binding.DataItem) {
+	value, _ := dataValue(item) //nolint:errcheck // Unreadable items are nil
+	r.show(item, value)
+}
+
+// show shows a value in the row; source is nil for values that are not
+// items of a bound list, which are read-only as a whole
+func (r *listRow) show(source binding.DataItem, value any) {
+	r.mu.Lock()
+	r.source = source
+	r.value = value
+	r.mu.Unlock()
+
+	r.trigger()
+}
+
+// get returns the item of the row
+func (r *listRow) get() any {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.value
+}
+
+func (r *listRow) addListener(l binding.DataListener) {
+	fyne.Do(func() {
+		r.listeners = append(r.listeners, l)
+		l.DataChanged()
+	})
+}
+
+func (r *listRow) removeListener(l binding.DataListener) {
+	fyne.Do(func() {
+		for i, listener := range r.listeners {
+			if listener == l {
+				r.listeners = append(r.listeners[:i], r.listeners[i+1:]...)
+				return
+			}
+		}
+	})
+}
+
+func (r *listRow) trigger() {
+	fyne.Do(func() {
+		for _, l := range r.listeners {
+			l.DataChanged()
+		}
+	})
+}
+
+// rowValue is the item of a row, or a field of it, as an untyped binding.
+// Fields are reached as a map, so item.Address.City is a valid path.
+type rowValue struct {
+	row  *listRow
+	path []string
+}
+
+// AddListener registers a listener notified when the row item changes
+func (v rowValue) AddListener(l binding.DataListener) {
+	v.row.addListener(l)
+}
+
+// RemoveListener removes a listener
+func (v rowValue) RemoveListener(l binding.DataListener) {
+	v.row.removeListener(l)
+}
+
+// Get returns the value, nil if the row is empty or the field is missing
+func (v rowValue) Get() (any, error) {
+	value := v.row.get()
+	for _, field := range v.path {
+		var ok bool
+		if value, ok = lookupField(value, field); !ok {
+			return nil, nil
+		}
+	}
+	return value, nil
+}
+
+// Set changes the value. Whole items are set in the list; fields can be set
+// on maps and on pointers to structs.
+func (v rowValue) Set(value any) error {
+	if len(v.path) == 0 {
+		v.row.mu.RLock()
+		source, ok := v.row.source.(binding.Untyped)
+		v.row.mu.RUnlock()
+		if !ok {
+			return fmt.Errorf("row item is read-only")
+		}
+		return source.Set(value)
+	}
+
+	parent, err := rowValue{row: v.row, path: v.path[:len(v.path)-1]}.Get()
+	if err != nil {
+		return err
+	}
+	if err := setField(parent, v.path[len(v.path)-1], value); err != nil {
+		return err
+	}
+
+	v.row.trigger()
+	return nil
+}
+
+// GetItem returns a field of the value
+func (v rowValue) GetItem(field string) (binding.DataItem, error) {
+	path := make([]string, len(v.path), len(v.path)+1)
+	copy(path, v.path)
+	return rowValue{row: v.row, path: append(path, field)}, nil
+}
+
+// Keys is part of binding.DataMap; row fields are only known per item
+func (v rowValue) Keys() []string {
+	return nil
+}
+
+// setField sets a key of a map or an exported field of a struct pointer.
+// The field name is matched case-insensitively.
+func setField(container any, field string, value any) error {
+	v := reflect.ValueOf(container)
+	for v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	switch {
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		val, err := assignable(value, v.Type().Elem())
+		if err != nil {
+			return err
+		}
+		v.SetMapIndex(reflect.ValueOf(field).Convert(v.Type().Key()), val)
+		return nil
+
+	case v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Struct:
+		f := v.Elem().FieldByNameFunc(func(name string) bool {
+			return strings.EqualFold(name, field)
+		})
+		if !f.IsValid() || !f.CanSet() {
+			return fmt.Errorf("cannot set field %s", field)
+		}
+		val, err := assignable(value, f.Type())
+		if err != nil {
+			return err
+		}
+		f.Set(val)
+		return nil
+
+	default:
+		return fmt.Errorf("cannot set field %s of %T", field, container)
+	}
+}
+
+// assignable converts a value to a type, if possible
+func assignable(value any, t reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(t), nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+	if v.Type().ConvertibleTo(t) && v.Kind() != reflect.String && t.Kind() != reflect.String {
+		return v.Convert(t), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot assign %T to %s", value, t)
+}
+
+// sameValue compares two values that may not be comparable, such as maps,
+// which are never considered equal
+func sameValue(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() {
+		return va.IsValid() == vb.IsValid()
+	}
+	if va.Type() != vb.Type() || !va.Comparable() || !vb.Comparable() {
+		return false
+	}
+	return va.Equal(vb)
+}
@@ -0,0 +1,220 @@
+package fylay
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+type testTodo struct {
+	Title string
+	Done  bool
+}
+
+// visibleObjects returns the objects rendered under obj, depth first
+func visibleObjects(obj fyne.CanvasObject) []fyne.CanvasObject {
+	objects := []fyne.CanvasObject{obj}
+	switch o := obj.(type) {
+	case *fyne.Container:
+		for _, child := range o.Objects {
+			objects = append(objects, visibleObjects(child)...)
+		}
+	case fyne.Widget:
+		for _, child := range test.WidgetRenderer(o).Objects() {
+			objects = append(objects, visibleObjects(child)...)
+		}
+	}
+	return objects
+}
+
+// visibleLabels returns the texts of the labels rendered under obj
+func visibleLabels(obj fyne.CanvasObject) []string {
+	var texts []string
+	for _, o := range visibleObjects(obj) {
+		if label, ok := o.(*widget.Label); ok && label.Visible() {
+			texts = append(texts, label.Text)
+		}
+	}
+	return texts
+}
+
+func TestListBinding(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<List id="todos" bind="todos" onselect="open">
+			<HBox>
+				<Checkbox id="done" bind="item.Done" />
+				<Label>${item.Title}</Label>
+			</HBox>
+		</List>
+		<List id="names" bind="names" />
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	ctx := builder.GetBindingContext()
+	todos := ctx.BindList("todos", []any{
+		&testTodo{Title: "Buy milk"},
+		&testTodo{Title: "Write code", Done: true},
+	})
+	ctx.BindStringList("names", []string{"Ada", "Grace"})
+
+	var selected *EventContext
+	builder.On("open", func(ev *EventContext) { selected = ev })
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	list := builder.GetWidget("todos").(*widget.List)
+	names := builder.GetWidget("names").(*widget.List)
+	w := test.NewWindow(container.NewGridWithRows(2, list, names))
+	defer w.Close()
+	w.Resize(fyne.NewSize(400, 600))
+
+	got := strings.Join(visibleLabels(list), ",")
+	if !strings.Contains(got, "Buy milk") || !strings.Contains(got, "Write code") {
+		t.Errorf("Expected rows from the template, got %q", got)
+	}
+	if got := strings.Join(visibleLabels(names), ","); !strings.Contains(got, "Ada") || !strings.Contains(got, "Grace") {
+		t.Errorf("Expected default rows for string items, got %q", got)
+	}
+
+	// Template IDs are not registered, every row has its own widgets
+	if builder.GetWidget("done") != nil {
+		t.Error("Expected IDs inside the row template to be ignored")
+	}
+
+	// Rows write back into their item
+	var checks []*widget.Check
+	for _, o := range visibleObjects(list) {
+		if check, ok := o.(*widget.Check); ok {
+			checks = append(checks, check)
+		}
+	}
+	if len(checks) < 2 {
+		t.Fatalf("Expected a checkbox per row, got %d", len(checks))
+	}
+	if checks[0].Checked || !checks[1].Checked {
+		t.Errorf("Expected checkboxes to follow the items")
+	}
+	checks[0].SetChecked(true)
+	first, _ := todos.GetValue(0)
+	if !first.(*testTodo).Done {
+		t.Error("Expected the row checkbox to update its item")
+	}
+
+	// Appended items are rendered
+	if err := todos.Append(&testTodo{Title: "Ship it"}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	list.Refresh()
+	if got := strings.Join(visibleLabels(list), ","); !strings.Contains(got, "Ship it") {
+		t.Errorf("Expected appended item to be rendered, got %q", got)
+	}
+
+	// onselect delivers the index and the item
+	list.Select(1)
+	if selected == nil {
+		t.Fatal("Expected onselect to fire")
+	}
+	second, _ := todos.GetValue(1)
+	if selected.Int() != 1 || selected.Item != second {
+		t.Errorf("Unexpected selection %q %v", selected.Value, selected.Item)
+	}
+}
+
+func TestRowScopesReleased(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<VBox>
+		<List id="names" bind="names">
+			<Label>${item}</Label>
+		</List>
+		<Tree id="nodes" bind="nodes">
+			<Label>${item.label}</Label>
+		</Tree>
+	</VBox>
+</Layout>
+`
+	builder := NewBuilder()
+	ctx := builder.GetBindingContext()
+	ctx.BindStringList("names", []string{"Ada", "Grace"})
+	ctx.BindList("nodes", []any{map[string]any{"id": "a", "label": "A"}})
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	content, err := builder.Build(layout)
+	if err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	w := test.NewWindow(content)
+	defer w.Close()
+	w.Resize(fyne.NewSize(400, 600))
+
+	list := builder.GetWidget("names").(*widget.List)
+	tree := builder.GetWidget("nodes").(*widget.Tree)
+	if got := strings.Join(visibleLabels(list), ","); !strings.Contains(got, "Grace") {
+		t.Fatalf("Expected rows from the template, got %q", got)
+	}
+	if got := strings.Join(visibleLabels(tree), ","); !strings.Contains(got, "A") {
+		t.Fatalf("Expected tree rows from the template, got %q", got)
+	}
+	if len(ctx.rows) == 0 {
+		t.Fatal("Expected the rendered rows to register their scope")
+	}
+	var keys []string
+	for key := range ctx.rows {
+		keys = append(keys, key)
+	}
+	for key := range ctx.Snapshot() {
+		if strings.HasPrefix(key, "_row") {
+			t.Errorf("Expected row scopes to be left out of the snapshot, got %q", key)
+		}
+	}
+
+	if _, err := builder.Rebuild(layout); err != nil {
+		t.Fatalf("Failed to rebuild: %v", err)
+	}
+	for _, key := range keys {
+		if _, ok := ctx.GetBinding(key); ok {
+			t.Errorf("Expected Rebuild to remove the row scope %q of the replaced tree", key)
+		}
+	}
+}
+
+func TestRenameItem(t *testing.T) {
+	tests := map[string]string{
+		"item":                       "_row1",
+		"item.Name | upper":          "_row1.Name | upper",
+		"items + item.count":         "items + _row1.count",
+		"'item' + item":              "'item' + _row1",
+		"remove(item.id, 'item.id')": "remove(_row1.id, 'item.id')",
+		"myitem.item":                "myitem.item",
+	}
+	for in, want := range tests {
+		if got := renameRoot(in, itemRoot, "_row1"); got != want {
+			t.Errorf("renameRoot(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	if got := renamePlaceholders("item: ${item.Title}", "_row1"); got != "item: ${_row1.Title}" {
+		t.Errorf("renamePlaceholders = %q", got)
+	}
+}
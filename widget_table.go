@@ -0,0 +1,318 @@
+package fylay
+
+import (
+	"log"
+	"sort"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/sandrolain/fylay/core"
+)
+
+// Sort direction markers shown in the header of the sorted column
+const (
+	sortAscending  = " ▲"
+	sortDescending = " ▼"
+)
+
+// tableColumn is a <Column> of a Table
+type tableColumn struct {
+	header   string
+	field    string
+	pipes    []bindPipe
+	width    float32 // 0 keeps the default width
+	sortable bool
+}
+
+// tableView holds the rows of a Table in display order
+type tableView struct {
+	mu      sync.RWMutex
+	data    binding.DataList
+	columns []tableColumn
+	rows    []any // Row values, in data order
+	order   []int // Data indexes, in display order
+	sortCol int   // Sorted column, -1 if unsorted
+	desc    bool
+}
+
+// buildTable builds a Table of the items of a bound list, typically maps or
+// structs, with a column for each <Column> child:
+//
+//	<Table bind="people" onselect="openPerson">
+//		<Column header="Name" field="name" width="200" />
+//		<Column header="Salary" field="salary | currency:EUR" />
+//	</Table>
+//
+// field is a key or struct field of the row, optionally followed by converter
+// pipes; the width can also come from the column style. Tapping a header sorts
+// the rows by that column, unless the column has sortable="false".
+// onselect receives the index of the row in the bound list as value and the
+// row in EventContext.Item.
+func (b *Builder) buildTable(elem Element, style map[string]string) fyne.CanvasObject {
+	ctx := b.GetBindingContext()
+
+	var listData binding.DataList
+	if key := ParseBindAttribute(elem.GetAttr("bind")); key != "" {
+		if data, ok := ctx.GetBinding(key); ok {
+			// Bindings that are not lists are ignored
+			listData, _ = data.(binding.DataList) //nolint:errcheck // Checked below
+		} else {
+			listData = ctx.BindList(key, nil)
+		}
+	}
+	if listData == nil {
+		listData = binding.NewUntypedList()
+	}
+
+	view := &tableView{data: listData, columns: b.tableColumns(elem), sortCol: -1}
+	view.reload()
+
+	table := widget.NewTableWithHeaders(
+		func() (int, int) {
+			return view.length(), len(view.columns)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			if label, ok := obj.(*widget.Label); ok {
+				label.SetText(view.cell(id.Row, id.Col))
+			}
+		})
+	table.ShowHeaderColumn = false
+
+	// Headers are buttons, tapping one sorts by its column
+	table.CreateHeader = func() fyne.CanvasObject {
+		btn := widget.NewButton("", nil)
+		btn.Importance = widget.LowImportance
+		return btn
+	}
+	table.UpdateHeader = func(id widget.TableCellID, obj fyne.CanvasObject) {
+		btn, ok := obj.(*widget.Button)
+		if !ok || id.Col < 0 || id.Col >= len(view.columns) {
+			return
+		}
+		btn.SetText(view.header(id.Col))
+		col := id.Col
+		btn.OnTapped = func() {
+			if view.sortBy(col) {
+				table.Refresh()
+			}
+		}
+	}
+
+	for i, col := range view.columns {
+		if col.width > 0 {
+			table.SetColumnWidth(i, col.width)
+		}
+	}
+
+	// Data changes reload the rows, keeping the sort, until the table is
+	// replaced
+	scope := b.buildingScope()
+	scope.listen(listData, binding.NewDataListener(func() {
+		view.reload()
+		table.Refresh()
+	}))
+
+	if elem.GetAttr(eventSelection) != "" {
+		table.OnSelected = func(id widget.TableCellID) {
+			index, item := view.row(id.Row)
+			if index < 0 {
+				return
+			}
+			b.fireEvent(eventSelection, &elem, table, selectionValue{id: index, item: item})
+		}
+	}
+
+	// Register widget with ID before applying styles
+	if elem.ID != "" {
+		ctx.RegisterWidget(elem.ID, table)
+		b.RegisterWidget(elem.ID, table)
+		scope.addTable(elem.ID, view)
+	}
+
+	styled := core.ApplyMinSize(table, style)
+	b.RegisterElement(elem.ID, styled)
+
+	return styled
+}
+
+// TableRow returns the row where the Table with the given ID shows the item
+// at index in its bound list, the value received by onselect. Rows differ
+// from indexes once the table is sorted. It returns -1 if there is no such
+// table or item.
+func (b *Builder) TableRow(id string, index int) int {
+	view, ok := b.currentScope().table(id)
+	if !ok {
+		return -1
+	}
+	return view.displayed(index)
+}
+
+// tableColumns parses the <Column> children of a Table
+func (b *Builder) tableColumns(elem Element) []tableColumn {
+	ctx := b.GetBindingContext()
+
+	var columns []tableColumn
+	for _, child := range elem.Children {
+		if child.XMLName.Local != "Column" {
+			continue
+		}
+
+		field, pipes := parseBindExpr(child.GetAttr("field"))
+		if err := ctx.resolvePipes(pipes); err != nil {
+			log.Printf("fylay: invalid column field %q: %v", child.GetAttr("field"), err)
+			pipes = nil
+		}
+
+		col := tableColumn{
+			header:   child.GetAttr("header"),
+			field:    field,
+			pipes:    pipes,
+			sortable: child.GetAttr("sortable") != "false",
+		}
+		if col.header == "" {
+			col.header = field
+		}
+
+		width := child.GetAttr("width")
+		if width == "" {
+			width = b.ComputeStyle(child)["width"]
+		}
+		if w, err := core.ParseSize(width); err == nil {
+			col.width = w
+		}
+
+		columns = append(columns, col)
+	}
+
+	return columns
+}
+
+// reload reads the rows from the bound list and sorts them again
+func (v *tableView) reload() {
+	rows := make([]any, v.data.Length())
+	for i := range rows {
+		if item, err := v.data.GetItem(i); err == nil {
+			rows[i], _ = dataValue(item) //nolint:errcheck // Unreadable rows are nil
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.rows = rows
+	v.order = make([]int, len(rows))
+	for i := range v.order {
+		v.order[i] = i
+	}
+	v.sortLocked()
+}
+
+// length returns the number of rows
+func (v *tableView) length() int {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return len(v.order)
+}
+
+// row returns the data index and the value of a displayed row
+func (v *tableView) row(displayed int) (int, any) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if displayed < 0 || displayed >= len(v.order) {
+		return -1, nil
+	}
+	index := v.order[displayed]
+	return index, v.rows[index]
+}
+
+// displayed returns the displayed row of a data index, -1 if out of range
+func (v *tableView) displayed(index int) int {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	for row, i := range v.order {
+		if i == index {
+			return row
+		}
+	}
+	return -1
+}
+
+// value returns the raw value of a column for a row
+func (v *tableView) value(row any, col int) any {
+	value, ok := lookupField(row, v.columns[col].field)
+	if !ok {
+		return nil
+	}
+	return value
+}
+
+// cell returns the text of a displayed cell
+func (v *tableView) cell(displayed, col int) string {
+	_, row := v.row(displayed)
+	if row == nil || col < 0 || col >= len(v.columns) {
+		return ""
+	}
+
+	value := v.value(row, col)
+	for _, p := range v.columns[col].pipes {
+		var err error
+		if value, err = p.conv.to(value, p.arg); err != nil {
+			return ""
+		}
+	}
+	return formatValue(value)
+}
+
+// header returns the header of a column, with the sort direction if sorted
+func (v *tableView) header(col int) string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	header := v.columns[col].header
+	if col == v.sortCol {
+		if v.desc {
+			return header + sortDescending
+		}
+		return header + sortAscending
+	}
+	return header
+}
+
+// sortBy sorts by a column, reversing the order if already sorted by it.
+// It returns false if the column is not sortable.
+func (v *tableView) sortBy(col int) bool {
+	if !v.columns[col].sortable {
+		return false
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.sortCol == col {
+		v.desc = !v.desc
+	} else {
+		v.sortCol, v.desc = col, false
+	}
+	v.sortLocked()
+	return true
+}
+
+// sortLocked sorts the display order; rows with equal values keep their
+// data order. The caller must hold v.mu.
+func (v *tableView) sortLocked() {
+	if v.sortCol < 0 {
+		return
+	}
+	sort.SliceStable(v.order, func(i, j int) bool {
+		a := v.value(v.rows[v.order[i]], v.sortCol)
+		b := v.value(v.rows[v.order[j]], v.sortCol)
+		if v.desc {
+			a, b = b, a
+		}
+		return compareValues("<", a, b)
+	})
+}
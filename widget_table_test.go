@@ -0,0 +1,94 @@
+package fylay
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestTableBinding(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<Table id="people" bind="people" onselect="open">
+		<Column header="Name" field="name" width="200" />
+		<Column header="Age" field="age" />
+		<Column header="Salary" field="salary | currency:EUR" sortable="false" />
+	</Table>
+</Layout>
+`
+	builder := NewBuilder()
+	people := builder.GetBindingContext().BindList("people", []any{
+		map[string]any{"name": "Carol", "age": 35, "salary": 3000.0},
+		map[string]any{"name": "Alice", "age": 28, "salary": 2500.0},
+		map[string]any{"name": "Bob", "age": 41, "salary": 4100.0},
+	})
+
+	var selected *EventContext
+	builder.On("open", func(ev *EventContext) { selected = ev })
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	table := builder.GetWidget("people").(*widget.Table)
+	cell := func(row, col int) string {
+		label := widget.NewLabel("")
+		table.UpdateCell(widget.TableCellID{Row: row, Col: col}, label)
+		return label.Text
+	}
+	header := func(col int) *widget.Button {
+		btn := widget.NewButton("", nil)
+		table.UpdateHeader(widget.TableCellID{Row: -1, Col: col}, btn)
+		return btn
+	}
+
+	if rows, cols := table.Length(); rows != 3 || cols != 3 {
+		t.Fatalf("Expected 3x3 table, got %dx%d", rows, cols)
+	}
+	if cell(0, 0) != "Carol" || cell(0, 2) != "€3000.00" {
+		t.Errorf("Unexpected first row %q %q", cell(0, 0), cell(0, 2))
+	}
+	if header(1).Text != "Age" {
+		t.Errorf("Unexpected header %q", header(1).Text)
+	}
+
+	// Tapping a header sorts by its column, tapping again reverses the order
+	test.Tap(header(1))
+	if cell(0, 0) != "Alice" || cell(2, 0) != "Bob" || header(1).Text != "Age ▲" {
+		t.Errorf("Expected rows sorted by age, got %q %q, header %q", cell(0, 0), cell(2, 0), header(1).Text)
+	}
+	test.Tap(header(1))
+	if cell(0, 0) != "Bob" || header(1).Text != "Age ▼" {
+		t.Errorf("Expected rows sorted by age descending, got %q, header %q", cell(0, 0), header(1).Text)
+	}
+	test.Tap(header(2))
+	if cell(0, 0) != "Bob" || header(2).Text != "Salary" {
+		t.Error("Expected non sortable column to keep the order")
+	}
+
+	// Selection reports the index in the bound list
+	table.Select(widget.TableCellID{Row: 2, Col: 1})
+	if selected == nil {
+		t.Fatal("Expected onselect to fire")
+	}
+	item, _ := selected.Item.(map[string]any)
+	if selected.Int() != 1 || item["name"] != "Alice" {
+		t.Errorf("Unexpected selection %q %v", selected.Value, selected.Item)
+	}
+
+	// Data changes refresh the table, keeping the sort
+	if err := people.Append(map[string]any{"name": "Dave", "age": 50, "salary": 5000.0}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if rows, _ := table.Length(); rows != 4 || cell(0, 0) != "Dave" {
+		t.Errorf("Expected appended row first, got %d rows, %q", rows, cell(0, 0))
+	}
+}
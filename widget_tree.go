@@ -0,0 +1,356 @@
+package fylay
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/sandrolain/fylay/core"
+)
+
+// treeFields names the fields of bound tree nodes
+type treeFields struct {
+	id       string
+	label    string
+	children string
+}
+
+// treeNodes is the hierarchy shown by a Tree, indexed by node ID
+type treeNodes struct {
+	mu       sync.RWMutex
+	children map[widget.TreeNodeID][]widget.TreeNodeID // The empty ID lists the roots
+	branches map[widget.TreeNodeID]bool
+	labels   map[widget.TreeNodeID]string
+	items    map[widget.TreeNodeID]any
+	open     []widget.TreeNodeID // Branches open when the tree is built
+}
+
+// newTreeNodes creates an empty hierarchy
+func newTreeNodes() *treeNodes {
+	return &treeNodes{
+		children: make(map[widget.TreeNodeID][]widget.TreeNodeID),
+		branches: make(map[widget.TreeNodeID]bool),
+		labels:   make(map[widget.TreeNodeID]string),
+		items:    make(map[widget.TreeNodeID]any),
+	}
+}
+
+// buildTree builds a Tree from nested <Node> children or from bound data:
+//
+//	<Tree onselect="openFile">
+//		<Node label="Documents" open="true">
+//			<Node label="report.pdf" size="2 MB" />
+//		</Node>
+//	</Tree>
+//
+//	<Tree bind="categories" onopen="loadCategory" />
+//
+// Bound data is a list of root nodes, maps or structs with a label and a list
+// of children; strings are leaves. The field names default to label, children
+// and id and can be changed with labelField, childrenField and idField. Nodes
+// with a list of children, even an empty one, are branches.
+//
+// A node is identified by its id field or value attribute, or else by the
+// path of labels from the root, e.g. "Documents/report.pdf"; IDs must be
+// unique. Children other than <Node> are the item template, where item refers
+// to the bound node, or to the attributes of a static one; without a template
+// each node shows its label. onselect, onopen and onclose receive the node ID
+// as value and the node in EventContext.Item.
+func (b *Builder) buildTree(elem Element, style map[string]string) fyne.CanvasObject {
+	ctx := b.GetBindingContext()
+
+	nodes := newTreeNodes()
+	var data binding.DataItem
+	if key := ParseBindAttribute(elem.GetAttr("bind")); key != "" {
+		if bound, ok := ctx.GetBinding(key); ok {
+			data = bound
+		} else {
+			data = ctx.BindList(key, nil)
+		}
+	} else {
+		nodes.addStatic("", elem.Children)
+	}
+
+	fields := treeFields{
+		id:       elem.GetAttr("idField"),
+		label:    elem.GetAttr("labelField"),
+		children: elem.GetAttr("childrenField"),
+	}
+	if fields.id == "" {
+		fields.id = "id"
+	}
+	if fields.label == "" {
+		fields.label = "label"
+	}
+	if fields.children == "" {
+		fields.children = "children"
+	}
+
+	// The template is made of the children that are not nodes
+	var templateChildren []Element
+	for _, child := range elem.Children {
+		if child.XMLName.Local != "Node" {
+			templateChildren = append(templateChildren, child)
+		}
+	}
+	template := itemTemplate(Element{Children: templateChildren})
+	scope := b.buildingScope()
+
+	// Rows are created and reused by the tree on the Fyne thread
+	var mu sync.Mutex
+	rows := make(map[fyne.CanvasObject]*listRow)
+
+	tree := widget.NewTree(nodes.childIDs, nodes.isBranch,
+		func(bool) fyne.CanvasObject {
+			if len(templateChildren) == 0 {
+				return widget.NewLabel("")
+			}
+
+			row := &listRow{}
+			obj := b.buildRow(scope, template, row)
+
+			mu.Lock()
+			rows[obj] = row
+			mu.Unlock()
+			return obj
+		},
+		func(uid widget.TreeNodeID, _ bool, obj fyne.CanvasObject) {
+			if label, ok := obj.(*widget.Label); ok && len(templateChildren) == 0 {
+				label.SetText(nodes.label(uid))
+				return
+			}
+
+			mu.Lock()
+			row := rows[obj]
+			mu.Unlock()
+			if row != nil {
+				row.show(nil, nodes.item(uid))
+			}
+		})
+
+	// The nodes are loaded right away, so the tree can be read after Build;
+	// data changes rebuild the hierarchy, keeping the open branches, until
+	// the tree is replaced
+	if data != nil {
+		nodes.reload(treeData(data), fields)
+		scope.listen(data, binding.NewDataListener(func() {
+			nodes.reload(treeData(data), fields)
+			tree.Refresh()
+		}))
+	}
+
+	for _, uid := range nodes.open {
+		tree.OpenBranch(uid)
+	}
+
+	if elem.GetAttr(eventSelection) != "" {
+		tree.OnSelected = func(uid widget.TreeNodeID) {
+			b.fireEvent(eventSelection, &elem, tree, selectionValue{id: uid, item: nodes.item(uid)})
+		}
+	}
+	if elem.GetAttr(eventOpen) != "" {
+		tree.OnBranchOpened = func(uid widget.TreeNodeID) {
+			b.fireEvent(eventOpen, &elem, tree, selectionValue{id: uid, item: nodes.item(uid)})
+		}
+	}
+	if elem.GetAttr(eventClose) != "" {
+		tree.OnBranchClosed = func(uid widget.TreeNodeID) {
+			b.fireEvent(eventClose, &elem, tree, selectionValue{id: uid, item: nodes.item(uid)})
+		}
+	}
+
+	// Register widget with ID before applying styles
+	if elem.ID != "" {
+		ctx.RegisterWidget(elem.ID, tree)
+		b.RegisterWidget(elem.ID, tree)
+	}
+
+	styled := core.ApplyMinSize(tree, style)
+	b.RegisterElement(elem.ID, styled)
+
+	return styled
+}
+
+// treeData returns the root nodes held by a binding: the items of a list, or
+// the value of any other binding
+func treeData(data binding.DataItem) any {
+	list, ok := data.(binding.DataList)
+	if !ok {
+		value, _ := dataValue(data) //nolint:errcheck // Unreadable data shows no nodes
+		return value
+	}
+
+	roots := make([]any, list.Length())
+	for i := range roots {
+		if item, err := list.GetItem(i); err == nil {
+			roots[i], _ = dataValue(item) //nolint:errcheck // Unreadable nodes are nil
+		}
+	}
+	return roots
+}
+
+// addStatic adds the <Node> elements to the children of parent
+func (n *treeNodes) addStatic(parent widget.TreeNodeID, elems []Element) {
+	for _, child := range elems {
+		if child.XMLName.Local != "Node" {
+			continue
+		}
+
+		label := child.GetAttr("label")
+		if label == "" {
+			label = strings.TrimSpace(child.Content)
+		}
+		uid := child.GetAttr("value")
+		if uid == "" {
+			uid = nodePath(parent, label)
+		}
+
+		item := map[string]any{"id": uid, "label": label}
+		for _, attr := range child.Attributes {
+			if _, ok := item[attr.Name.Local]; !ok {
+				item[attr.Name.Local] = attr.Value
+			}
+		}
+
+		n.add(parent, uid, label, item)
+		if child.GetAttr("branch") == "true" || hasNodes(child) {
+			n.branches[uid] = true
+			n.addStatic(uid, child.Children)
+		}
+		if child.GetAttr("open") == "true" {
+			n.open = append(n.open, uid)
+		}
+	}
+}
+
+// hasNodes reports whether an element has <Node> children
+func hasNodes(elem Element) bool {
+	for _, child := range elem.Children {
+		if child.XMLName.Local == "Node" {
+			return true
+		}
+	}
+	return false
+}
+
+// reload replaces the hierarchy with the nodes of bound data
+func (n *treeNodes) reload(data any, fields treeFields) {
+	loaded := newTreeNodes()
+	loaded.addBound("", data, fields)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.children = loaded.children
+	n.branches = loaded.branches
+	n.labels = loaded.labels
+	n.items = loaded.items
+}
+
+// addBound adds the nodes of a list, or a single node, to the children of
+// parent
+func (n *treeNodes) addBound(parent widget.TreeNodeID, data any, fields treeFields) {
+	list, ok := sliceItems(data)
+	if !ok {
+		if data == nil {
+			return
+		}
+		list = []any{data}
+	}
+
+	for _, node := range list {
+		label := formatValue(node)
+		if value, ok := lookupField(node, fields.label); ok {
+			label = formatValue(value)
+		}
+		uid := nodePath(parent, label)
+		if value, ok := lookupField(node, fields.id); ok && formatValue(value) != "" {
+			uid = formatValue(value)
+		}
+
+		n.add(parent, uid, label, node)
+		if children, ok := lookupField(node, fields.children); ok && !isNilSlice(children) {
+			if _, isList := sliceItems(children); isList {
+				n.branches[uid] = true
+				n.addBound(uid, children, fields)
+			}
+		}
+	}
+}
+
+// add adds a node to the children of parent
+func (n *treeNodes) add(parent, uid widget.TreeNodeID, label string, item any) {
+	n.children[parent] = append(n.children[parent], uid)
+	n.labels[uid] = label
+	n.items[uid] = item
+}
+
+// nodePath returns the default ID of a node, the path of labels from the root
+func nodePath(parent widget.TreeNodeID, label string) widget.TreeNodeID {
+	if parent == "" {
+		return label
+	}
+	return parent + "/" + label
+}
+
+// sliceItems returns the elements of a slice or array value
+func sliceItems(value any) ([]any, bool) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	items := make([]any, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+	return items, true
+}
+
+// isNilSlice reports whether a value is a nil slice, such as the children of
+// a struct node without any
+func isNilSlice(value any) bool {
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Slice && v.IsNil()
+}
+
+// childIDs returns the IDs of the children of a node, or of the roots for
+// the empty ID
+func (n *treeNodes) childIDs(uid widget.TreeNodeID) []widget.TreeNodeID {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.children[uid]
+}
+
+// isBranch reports whether a node can have children
+func (n *treeNodes) isBranch(uid widget.TreeNodeID) bool {
+	if uid == "" {
+		return true
+	}
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.branches[uid]
+}
+
+// label returns the label of a node
+func (n *treeNodes) label(uid widget.TreeNodeID) string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.labels[uid]
+}
+
+// item returns the value of a node
+func (n *treeNodes) item(uid widget.TreeNodeID) any {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.items[uid]
+}
@@ -0,0 +1,197 @@
+package fylay
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestTreeStatic(t *testing.T) {
+	_ = test.NewApp()
+
+	layoutXML := `
+<Layout>
+	<Tree id="files" onselect="open" onopen="expand" onclose="collapse">
+		<Node label="Documents" open="true">
+			<Node label="report.pdf" size="2 MB" />
+			<Node label="Archive" value="archive" branch="true" />
+		</Node>
+		<Node label="notes.txt" />
+		<HBox>
+			<Label>${item.label}</Label>
+			<Label>${item.size}</Label>
+		</HBox>
+	</Tree>
+</Layout>
+`
+	builder := NewBuilder()
+
+	var events []*EventContext
+	record := func(ev *EventContext) { events = append(events, ev) }
+	builder.On("open", record)
+	builder.On("expand", record)
+	builder.On("collapse", record)
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	tree := builder.GetWidget("files").(*widget.Tree)
+	if got := tree.ChildUIDs(""); strings.Join(got, ",") != "Documents,notes.txt" {
+		t.Errorf("Unexpected roots %v", got)
+	}
+	if got := tree.ChildUIDs("Documents"); strings.Join(got, ",") != "Documents/report.pdf,archive" {
+		t.Errorf("Unexpected children %v", got)
+	}
+	if !tree.IsBranch("archive") || tree.IsBranch("notes.txt") {
+		t.Error("Expected nodes with children or branch=true to be branches")
+	}
+	if !tree.IsBranchOpen("Documents") {
+		t.Error("Expected open=true to open the branch")
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no events while building, got %d", len(events))
+	}
+
+	w := test.NewWindow(tree)
+	defer w.Close()
+	w.Resize(fyne.NewSize(300, 400))
+
+	got := strings.Join(visibleLabels(tree), ",")
+	if !strings.Contains(got, "report.pdf,2 MB") || !strings.Contains(got, "notes.txt") {
+		t.Errorf("Expected nodes from the template, got %q", got)
+	}
+
+	tree.Select("Documents/report.pdf")
+	tree.CloseBranch("Documents")
+	tree.OpenBranch("archive")
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+	if events[0].Type != "onselect" || events[0].Value != "Documents/report.pdf" {
+		t.Errorf("Unexpected select event %s %q", events[0].Type, events[0].Value)
+	}
+	if item := events[0].Item.(map[string]any); item["size"] != "2 MB" {
+		t.Errorf("Expected the node attributes as item, got %v", item)
+	}
+	if events[1].Type != "onclose" || events[1].Value != "Documents" {
+		t.Errorf("Unexpected close event %s %q", events[1].Type, events[1].Value)
+	}
+	if events[2].Type != "onopen" || events[2].Value != "archive" {
+		t.Errorf("Unexpected open event %s %q", events[2].Type, events[2].Value)
+	}
+}
+
+func TestTreeBinding(t *testing.T) {
+	_ = test.NewApp()
+
+	type category struct {
+		Name     string
+		Children []any
+	}
+
+	layoutXML := `
+<Layout>
+	<Tree id="categories" bind="categories" labelField="Name" onselect="open" />
+</Layout>
+`
+	builder := NewBuilder()
+	categories := builder.GetBindingContext().BindList("categories", []any{
+		category{Name: "Books", Children: []any{
+			category{Name: "Fiction"},
+			"Poetry",
+		}},
+		map[string]any{"id": "music", "Name": "Music", "children": []any{}},
+	})
+
+	var selected *EventContext
+	builder.On("open", func(ev *EventContext) { selected = ev })
+
+	layout, err := builder.LoadLayout(strings.NewReader(layoutXML))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	tree := builder.GetWidget("categories").(*widget.Tree)
+	if got := tree.ChildUIDs(""); strings.Join(got, ",") != "Books,music" {
+		t.Errorf("Unexpected roots %v", got)
+	}
+	if got := tree.ChildUIDs("Books"); strings.Join(got, ",") != "Books/Fiction,Books/Poetry" {
+		t.Errorf("Unexpected children %v", got)
+	}
+	if !tree.IsBranch("music") || tree.IsBranch("Books/Poetry") {
+		t.Error("Expected nodes with a children list to be branches")
+	}
+	if tree.IsBranch("Books/Fiction") {
+		t.Error("Expected nodes with nil children to be leaves")
+	}
+
+	// Default nodes show their label
+	w := test.NewWindow(tree)
+	defer w.Close()
+	w.Resize(fyne.NewSize(300, 400))
+	tree.OpenBranch("Books")
+	if got := strings.Join(visibleLabels(tree), ","); got != "Books,Fiction,Poetry,Music" {
+		t.Errorf("Unexpected labels %q", got)
+	}
+
+	tree.Select("Books/Fiction")
+	if selected == nil || selected.Item.(category).Name != "Fiction" {
+		t.Fatal("Expected onselect with the bound node")
+	}
+
+	// Data changes rebuild the tree
+	if err := categories.Append("Games"); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if got := tree.ChildUIDs(""); len(got) != 3 || got[2] != "Games" {
+		t.Errorf("Expected appended root, got %v", got)
+	}
+}
+
+// quietList is a list binding that does not notify new listeners, as the
+// notification is delivered on a later frame outside the test driver
+type quietList struct {
+	binding.UntypedList
+}
+
+func (quietList) AddListener(binding.DataListener) {}
+
+func TestTreeBindingLoadedOnBuild(t *testing.T) {
+	_ = test.NewApp()
+
+	builder := NewBuilder()
+	ctx := builder.GetBindingContext()
+	list := binding.NewUntypedList()
+	_ = list.Set([]any{map[string]any{"id": "a", "label": "A", "children": []any{"b"}}}) //nolint:errcheck // Untyped lists always set
+	ctx.mu.Lock()
+	ctx.data["nodes"] = quietList{list}
+	ctx.mu.Unlock()
+
+	layout, err := builder.LoadLayout(strings.NewReader(`<Layout><Tree id="nodes" bind="nodes" /></Layout>`))
+	if err != nil {
+		t.Fatalf("Failed to load layout: %v", err)
+	}
+	if _, err := builder.Build(layout); err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	tree := builder.GetWidget("nodes").(*widget.Tree)
+	if got := strings.Join(tree.ChildUIDs(""), ","); got != "a" {
+		t.Errorf("Expected the nodes right after Build, got %q", got)
+	}
+	if got := strings.Join(tree.ChildUIDs("a"), ","); got != "a/b" {
+		t.Errorf("Expected the children right after Build, got %q", got)
+	}
+}
@@ -1,62 +1,66 @@
 package fylay
 
 import (
+	"slices"
 	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/widget"
+
+	"github.com/sandrolain/fylay/core"
 )
 
 // buildCheckbox costruisce un widget Checkbox
 func (b *Builder) buildCheckbox(elem Element, style map[string]string) fyne.CanvasObject {
-	label := elem.getAttr("label")
+	label := elem.GetAttr("label")
 	if label == "" {
 		label = elem.Content
 	}
 
-	checked := elem.getAttr("checked") == attrValueTrue
+	checked := elem.GetAttr("checked") == attrValueTrue
 
-	check := widget.NewCheck(label, nil)
+	obj, check := b.newCheck(&elem)
+	check.Text = label
 	check.Checked = checked
 
-	// Handle onchange event
-	onchange := elem.getAttr("onchange")
-	if onchange != "" {
-		if callback, ok := b.eventCallbacks[onchange]; ok {
-			check.OnChanged = func(checked bool) {
-				ctx := &EventContext{
-					EventName: onchange,
-					Target:    check,
-					TargetID:  elem.ID,
-					Value:     strconv.FormatBool(checked),
-				}
-				callback(ctx)
-			}
+	// Regole di validazione: required richiede la spunta
+	field := b.addField(&elem, nil, func() string {
+		if check.Checked {
+			return attrValueTrue
+		}
+		return ""
+	}, nil)
+
+	// Handle onchange event, resolving the callback when it fires
+	if elem.GetAttr(eventChange) != "" || field != nil {
+		check.OnChanged = func(checked bool) {
+			field.changed()
+			b.fireEvent(eventChange, &elem, check, checked)
 		}
 	}
 
 	// Handle data binding
-	if bindAttr := elem.getAttr("bind"); bindAttr != "" {
+	if bindAttr := elem.GetAttr("bind"); bindAttr != "" {
 		ctx := b.GetBindingContext()
-		key := ParseBindAttribute(bindAttr)
-		boolData := ctx.BindBool(key, checked)
-		check.Bind(boolData)
+		if boolData := ctx.boolBinding(bindAttr, checked); boolData != nil {
+			check.Bind(boolData)
+		}
 	}
 
 	// Register widget with ID before applying styles
 	if elem.ID != "" {
 		b.GetBindingContext().RegisterWidget(elem.ID, check)
-		b.widgets[elem.ID] = check // Original widget
+		b.RegisterWidget(elem.ID, check)
 	}
 
 	// Apply common styles (width, height) - may wrap in container
-	styled := applyMinSize(check, style)
+	styled := core.ApplyMinSize(obj, style)
 
 	// Store the final styled version
-	if elem.ID != "" {
-		b.elements[elem.ID] = styled
-	}
+	b.RegisterElement(elem.ID, styled)
 
 	return styled
 }
@@ -69,60 +73,54 @@ func (b *Builder) buildSelect(elem Element, style map[string]string) fyne.Canvas
 	// Parse Option children
 	for _, child := range elem.Children {
 		if child.XMLName.Local == "Option" {
-			value := child.getAttr("value")
+			value := child.GetAttr("value")
 			if value == "" {
 				value = child.Content
 			}
 			options = append(options, value)
 
-			if child.getAttr("selected") == attrValueTrue {
+			if child.GetAttr("selected") == attrValueTrue {
 				selected = value
 			}
 		}
 	}
 
-	sel := widget.NewSelect(options, nil)
+	obj, sel := b.newSelect(&elem)
+	sel.Options = options
 	if selected != "" {
 		sel.SetSelected(selected)
 	}
 
-	// Handle onchange event
-	onchange := elem.getAttr("onchange")
-	if onchange != "" {
-		if callback, ok := b.entryCallbacks[onchange]; ok {
-			sel.OnChanged = func(value string) {
-				ctx := &EventContext{
-					EventName: onchange,
-					Target:    sel,
-					TargetID:  elem.ID,
-					Value:     value,
-				}
-				callback(ctx)
-			}
+	// Regole di validazione sul valore selezionato
+	field := b.addField(&elem, nil, func() string { return sel.Selected }, nil)
+
+	// Handle onchange event, resolving the callback when it fires
+	if elem.GetAttr(eventChange) != "" || field != nil {
+		sel.OnChanged = func(value string) {
+			field.changed()
+			b.fireEvent(eventChange, &elem, sel, value)
 		}
 	}
 
 	// Handle data binding
-	if bindAttr := elem.getAttr("bind"); bindAttr != "" {
+	if bindAttr := elem.GetAttr("bind"); bindAttr != "" {
 		ctx := b.GetBindingContext()
-		key := ParseBindAttribute(bindAttr)
-		strData := ctx.BindString(key, selected)
-		sel.Bind(strData)
+		if strData := ctx.stringBinding(bindAttr, selected); strData != nil {
+			sel.Bind(strData)
+		}
 	}
 
 	// Register widget with ID before applying styles
 	if elem.ID != "" {
 		b.GetBindingContext().RegisterWidget(elem.ID, sel)
-		b.widgets[elem.ID] = sel // Original widget
+		b.RegisterWidget(elem.ID, sel)
 	}
 
 	// Apply common styles (width, height) - may wrap in container
-	styled := applyMinSize(sel, style)
+	styled := core.ApplyMinSize(obj, style)
 
 	// Store the final styled version
-	if elem.ID != "" {
-		b.elements[elem.ID] = styled
-	}
+	b.RegisterElement(elem.ID, styled)
 
 	return styled
 }
@@ -130,14 +128,14 @@ func (b *Builder) buildSelect(elem Element, style map[string]string) fyne.Canvas
 // buildProgressBar costruisce un widget ProgressBar
 func (b *Builder) buildProgressBar(elem Element, style map[string]string) fyne.CanvasObject {
 	value := 0.0
-	if v := elem.getAttr("value"); v != "" {
+	if v := elem.GetAttr("value"); v != "" {
 		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
 			value = parsed
 		}
 	}
 
 	max := 1.0
-	if m := elem.getAttr("max"); m != "" {
+	if m := elem.GetAttr("max"); m != "" {
 		if parsed, err := strconv.ParseFloat(m, 64); err == nil {
 			max = parsed
 		}
@@ -148,26 +146,24 @@ func (b *Builder) buildProgressBar(elem Element, style map[string]string) fyne.C
 	progress.SetValue(value)
 
 	// Handle data binding
-	if bindAttr := elem.getAttr("bind"); bindAttr != "" {
+	if bindAttr := elem.GetAttr("bind"); bindAttr != "" {
 		ctx := b.GetBindingContext()
-		key := ParseBindAttribute(bindAttr)
-		floatData := ctx.BindFloat(key, value)
-		progress.Bind(floatData)
+		if floatData := ctx.floatBinding(bindAttr, value); floatData != nil {
+			progress.Bind(floatData)
+		}
 	}
 
 	// Register widget with ID before applying styles
 	if elem.ID != "" {
 		b.GetBindingContext().RegisterWidget(elem.ID, progress)
-		b.widgets[elem.ID] = progress // Original widget
+		b.RegisterWidget(elem.ID, progress)
 	}
 
 	// Apply common styles (width, height) - may wrap in container
-	styled := applyMinSize(progress, style)
+	styled := core.ApplyMinSize(progress, style)
 
 	// Store the final styled version
-	if elem.ID != "" {
-		b.elements[elem.ID] = styled
-	}
+	b.RegisterElement(elem.ID, styled)
 
 	return styled
 }
@@ -175,90 +171,93 @@ func (b *Builder) buildProgressBar(elem Element, style map[string]string) fyne.C
 // buildSlider costruisce un widget Slider
 func (b *Builder) buildSlider(elem Element, style map[string]string) fyne.CanvasObject {
 	min := 0.0
-	if m := elem.getAttr("min"); m != "" {
+	if m := elem.GetAttr("min"); m != "" {
 		if parsed, err := strconv.ParseFloat(m, 64); err == nil {
 			min = parsed
 		}
 	}
 
 	max := 100.0
-	if m := elem.getAttr("max"); m != "" {
+	if m := elem.GetAttr("max"); m != "" {
 		if parsed, err := strconv.ParseFloat(m, 64); err == nil {
 			max = parsed
 		}
 	}
 
 	value := min
-	if v := elem.getAttr("value"); v != "" {
+	if v := elem.GetAttr("value"); v != "" {
 		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
 			value = parsed
 		}
 	}
 
 	step := 1.0
-	if s := elem.getAttr("step"); s != "" {
+	if s := elem.GetAttr("step"); s != "" {
 		if parsed, err := strconv.ParseFloat(s, 64); err == nil {
 			step = parsed
 		}
 	}
 
-	slider := widget.NewSlider(min, max)
+	obj, slider := b.newSlider(&elem, min, max)
 	slider.Value = value
 	slider.Step = step
 
-	// Handle onchange event
-	onchange := elem.getAttr("onchange")
-	if onchange != "" {
-		if callback, ok := b.eventCallbacks[onchange]; ok {
-			slider.OnChanged = func(value float64) {
-				ctx := &EventContext{
-					EventName: onchange,
-					Target:    slider,
-					TargetID:  elem.ID,
-					Value:     strconv.FormatFloat(value, 'f', -1, 64),
-				}
-				callback(ctx)
-			}
+	// Handle onchange event, resolving the callback when it fires
+	if elem.GetAttr(eventChange) != "" {
+		slider.OnChanged = func(value float64) {
+			b.fireEvent(eventChange, &elem, slider, value)
 		}
 	}
 
 	// Handle data binding
-	if bindAttr := elem.getAttr("bind"); bindAttr != "" {
+	if bindAttr := elem.GetAttr("bind"); bindAttr != "" {
 		ctx := b.GetBindingContext()
-		key := ParseBindAttribute(bindAttr)
-		floatData := ctx.BindFloat(key, value)
-		slider.Bind(floatData)
+		if floatData := ctx.floatBinding(bindAttr, value); floatData != nil {
+			slider.Bind(floatData)
+		}
 	}
 
 	// Register widget with ID before applying styles
 	if elem.ID != "" {
 		b.GetBindingContext().RegisterWidget(elem.ID, slider)
-		b.widgets[elem.ID] = slider // Original widget
+		b.RegisterWidget(elem.ID, slider)
 	}
 
 	// Apply common styles (width, height) - may wrap in container
-	styled := applyMinSize(slider, style)
+	styled := core.ApplyMinSize(obj, style)
 
 	// Store the final styled version
-	if elem.ID != "" {
-		b.elements[elem.ID] = styled
-	}
+	b.RegisterElement(elem.ID, styled)
 
 	return styled
 }
 
 // buildImage costruisce un widget Image
 func (b *Builder) buildImage(elem Element, style map[string]string) fyne.CanvasObject {
-	src := elem.getAttr("src")
-	if src == "" {
+	src := elem.GetAttr("src")
+	bindAttr := elem.GetAttr("bind")
+	if src == "" && bindAttr == "" {
 		// Return empty rectangle if no source
 		rect := canvas.NewRectangle(nil)
 		rect.SetMinSize(fyne.NewSize(100, 100))
 		return rect
 	}
 
-	img, err := NewImageWidget(src)
-	if err != nil {
+	img := newImageWidget(src)
+	if bindAttr != "" {
+		// Bound source: src is the initial value, every change reloads the
+		// image until it is replaced
+		if data := b.GetBindingContext().stringBinding(bindAttr, src); data != nil {
+			b.buildingScope().listen(data, binding.NewDataListener(func() {
+				if value, err := data.Get(); err == nil && value != "" {
+					img.setSourceAsync(value)
+				}
+			}))
+		}
+	} else if img.isURL() {
+		// Remote images are downloaded in background so the build never blocks on the network
+		img.LoadAsync(nil)
+	} else if err := img.Load(); err != nil {
 		// Return error placeholder
 		rect := canvas.NewRectangle(nil)
 		rect.SetMinSize(fyne.NewSize(100, 100))
@@ -266,10 +265,10 @@ func (b *Builder) buildImage(elem Element, style map[string]string) fyne.CanvasO
 	}
 
 	// Apply size from attributes or style
-	if width := elem.getAttr("width"); width != "" {
-		if w, err := parseSize(width); err == nil {
-			if height := elem.getAttr("height"); height != "" {
-				if h, err := parseSize(height); err == nil {
+	if width := elem.GetAttr("width"); width != "" {
+		if w, err := core.ParseSize(width); err == nil {
+			if height := elem.GetAttr("height"); height != "" {
+				if h, err := core.ParseSize(height); err == nil {
 					img.SetMinSize(fyne.NewSize(w, h))
 				}
 			}
@@ -277,7 +276,7 @@ func (b *Builder) buildImage(elem Element, style map[string]string) fyne.CanvasO
 	}
 
 	// Apply FillMode
-	fillMode := elem.getAttr("fillMode")
+	fillMode := elem.GetAttr("fillMode")
 	switch fillMode {
 	case "contain":
 		img.FillMode = canvas.ImageFillContain
@@ -290,20 +289,16 @@ func (b *Builder) buildImage(elem Element, style map[string]string) fyne.CanvasO
 	}
 
 	// Register widget with ID before applying styles
-	if elem.ID != "" {
-		b.widgets[elem.ID] = img // Original widget
-	}
+	b.RegisterWidget(elem.ID, img)
 
 	// Apply common styles (width, height) if not already set by attributes - may wrap in container
 	var styled fyne.CanvasObject = img
-	if elem.getAttr("width") == "" || elem.getAttr("height") == "" {
-		styled = applyMinSize(img, style)
+	if elem.GetAttr("width") == "" || elem.GetAttr("height") == "" {
+		styled = core.ApplyMinSize(img, style)
 	}
 
 	// Store the final styled version
-	if elem.ID != "" {
-		b.elements[elem.ID] = styled
-	}
+	b.RegisterElement(elem.ID, styled)
 
 	return styled
 }
@@ -316,13 +311,13 @@ func (b *Builder) buildRadioGroup(elem Element, style map[string]string) fyne.Ca
 	// Parse Radio children
 	for _, child := range elem.Children {
 		if child.XMLName.Local == "Radio" {
-			value := child.getAttr("value")
+			value := child.GetAttr("value")
 			if value == "" {
 				value = child.Content
 			}
 			options = append(options, value)
 
-			if child.getAttr("selected") == attrValueTrue {
+			if child.GetAttr("selected") == attrValueTrue {
 				selected = value
 			}
 		}
@@ -333,38 +328,123 @@ func (b *Builder) buildRadioGroup(elem Element, style map[string]string) fyne.Ca
 		radio.SetSelected(selected)
 	}
 
-	// Handle onchange event
-	onchange := elem.getAttr("onchange")
-	if onchange != "" {
-		if callback, ok := b.entryCallbacks[onchange]; ok {
-			radio.OnChanged = func(value string) {
-				ctx := &EventContext{
-					EventName: onchange,
-					Target:    radio,
-					TargetID:  elem.ID,
-					Value:     value,
+	// Handle data binding: RadioGroup has no Bind, so the selection and the
+	// string binding are kept in sync both ways until the group is replaced
+	var strData binding.String
+	if bindAttr := elem.GetAttr("bind"); bindAttr != "" {
+		strData = b.GetBindingContext().stringBinding(bindAttr, selected)
+		if strData != nil {
+			b.buildingScope().listen(strData, binding.NewDataListener(func() {
+				if value, err := strData.Get(); err == nil {
+					radio.SetSelected(value)
 				}
-				callback(ctx)
-			}
+			}))
 		}
 	}
 
-	// Note: RadioGroup doesn't support direct binding in Fyne
-	// Binding would need to be done through OnChanged callback
+	// Regole di validazione sul valore selezionato
+	field := b.addField(&elem, nil, func() string { return radio.Selected }, nil)
+
+	// Handle onchange event, resolving the callback when it fires
+	radio.OnChanged = func(value string) {
+		field.changed()
+		if strData != nil {
+			if current, err := strData.Get(); err == nil && current != value {
+				_ = strData.Set(value) //nolint:errcheck // Ignore error on set
+			}
+		}
+		b.fireEvent(eventChange, &elem, radio, value)
+	}
 
 	// Register widget with ID before applying styles
 	if elem.ID != "" {
 		b.GetBindingContext().RegisterWidget(elem.ID, radio)
-		b.widgets[elem.ID] = radio // Original widget
+		b.RegisterWidget(elem.ID, radio)
 	}
 
 	// Apply common styles (width, height) - may wrap in container
-	styled := applyMinSize(radio, style)
+	styled := core.ApplyMinSize(radio, style)
 
 	// Store the final styled version
+	b.RegisterElement(elem.ID, styled)
+
+	return styled
+}
+
+// buildCheckGroup costruisce un widget CheckGroup.
+// Il binding usa una lista di stringhe con i valori selezionati.
+func (b *Builder) buildCheckGroup(elem Element, style map[string]string) fyne.CanvasObject {
+	var options []string
+	var selected []string
+
+	// Parse Check children
+	for _, child := range elem.Children {
+		if child.XMLName.Local == "Check" {
+			value := child.GetAttr("value")
+			if value == "" {
+				value = child.Content
+			}
+			options = append(options, value)
+
+			if child.GetAttr("selected") == attrValueTrue {
+				selected = append(selected, value)
+			}
+		}
+	}
+
+	group := widget.NewCheckGroup(options, nil)
+	group.Horizontal = elem.GetAttr("horizontal") == attrValueTrue
+	group.Selected = selected
+
+	// Handle data binding: the selection and the list are kept in sync both
+	// ways until the group is replaced
+	var listData binding.StringList
+	if bindAttr := elem.GetAttr("bind"); bindAttr != "" {
+		ctx := b.GetBindingContext()
+		key := ParseBindAttribute(bindAttr)
+		if data, ok := ctx.GetBinding(key); ok {
+			// Bindings of other types are ignored
+			if l, ok := data.(binding.StringList); ok {
+				listData = l
+			}
+		} else {
+			listData = ctx.BindStringList(key, selected)
+		}
+
+		if listData != nil {
+			b.buildingScope().listen(listData, binding.NewDataListener(func() {
+				if values, err := listData.Get(); err == nil && !slices.Equal(values, group.Selected) {
+					group.SetSelected(values)
+				}
+			}))
+		}
+	}
+
+	// Regole di validazione sui valori selezionati, separati da virgole
+	field := b.addField(&elem, nil, func() string { return strings.Join(group.Selected, ",") }, nil)
+
+	// Handle onchange event, resolving the callback when it fires
+	group.OnChanged = func(values []string) {
+		field.changed()
+		if listData != nil {
+			if current, err := listData.Get(); err == nil && !slices.Equal(current, values) {
+				_ = listData.Set(values) //nolint:errcheck // Ignore error on set
+			}
+		}
+		b.fireEvent(eventChange, &elem, group, values)
+	}
+
+	// Register widget with ID before applying styles
 	if elem.ID != "" {
-		b.elements[elem.ID] = styled
+		b.GetBindingContext().RegisterWidget(elem.ID, group)
+		b.RegisterWidget(elem.ID, group)
 	}
 
+	// Apply common styles (width, height) - may wrap in container
+	styled := core.ApplyMinSize(group, style)
+
+	// Store the final styled version
+	b.RegisterElement(elem.ID, styled)
+
 	return styled
 }